@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -13,6 +12,7 @@ import (
 	invpkg "pulwar.isi.edu/sabres/orchestrator/inventory/pkg"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/manager/pkg"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/manager/protocol"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
 	netpkg "pulwar.isi.edu/sabres/orchestrator/sabres/network/pkg"
 	proto "pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
 )
@@ -79,18 +79,22 @@ func main() {
 	}
 	root.AddCommand(configureCmd)
 
+	var sliceGraph string
+	var explain bool
 	createNetworkSlice := &cobra.Command{
 		Use:   "slice <request-file>",
-		Short: "Create a slice given a cbs formatted request file",
+		Short: "Create a slice given a cbs formatted json or .sabres request file",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			addr := fmt.Sprintf("%s:%d", clientServer, clientPort)
 			cbsAddr := fmt.Sprintf("%s:%d", cbsServer, cbsPort)
 			networkAddr := fmt.Sprintf("%s:%d", networkServer, networkPort)
 			inventoryAddr := fmt.Sprintf("%s:%d", inventoryServer, inventoryPort)
-			createNetworkSliceFunc(addr, cbsAddr, networkAddr, inventoryAddr, args[0])
+			createNetworkSliceFunc(addr, cbsAddr, networkAddr, inventoryAddr, sliceGraph, args[0], explain)
 		},
 	}
+	createNetworkSlice.Flags().StringVar(&sliceGraph, "graph", "", "named network graph to solve against, the default graph if not set")
+	createNetworkSlice.Flags().BoolVar(&explain, "explain", false, "print the compiled constraints instead of creating the slice")
 	createCmd.AddCommand(createNetworkSlice)
 
 	deleteNetworkSlice := &cobra.Command{
@@ -100,7 +104,8 @@ func main() {
 		Run: func(cmd *cobra.Command, args []string) {
 			addr := fmt.Sprintf("%s:%d", clientServer, clientPort)
 			inventoryAddr := fmt.Sprintf("%s:%d", inventoryServer, inventoryPort)
-			deleteNetworkSliceFunc(addr, inventoryAddr, args[0])
+			networkAddr := fmt.Sprintf("%s:%d", networkServer, networkPort)
+			deleteNetworkSliceFunc(addr, inventoryAddr, networkAddr, args[0])
 		},
 	}
 	deleteCmd.AddCommand(deleteNetworkSlice)
@@ -118,7 +123,7 @@ func main() {
 
 	configureConfig := &cobra.Command{
 		Use:   "slice <uuid>",
-		Short: "Show current slices",
+		Short: "Address a slice and print the configuration of each node",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			addr := fmt.Sprintf("%s:%d", clientServer, clientPort)
@@ -131,18 +136,16 @@ func main() {
 	root.Execute()
 }
 
-func createNetworkSliceFunc(mgmtAddr, cbsAddr, netAddr, invAddr, fileName string) {
+func createNetworkSliceFunc(mgmtAddr, cbsAddr, netAddr, invAddr, graphName, fileName string, explain bool) {
 
-	contents, err := ioutil.ReadFile(fileName)
+	constraints, err := constraint.ReadFile(fileName)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	var constraints []*proto.Constraint
-
-	err = json.Unmarshal(contents, &constraints)
-	if err != nil {
-		log.Fatal(err)
+	if explain {
+		explainFunc(constraints)
+		return
 	}
 
 	pkg.WithManagement(mgmtAddr, func(c protocol.ManagerClient) error {
@@ -152,9 +155,10 @@ func createNetworkSliceFunc(mgmtAddr, cbsAddr, netAddr, invAddr, fileName string
 			NetAddr:     netAddr,
 			InvAddr:     invAddr,
 			Constraints: constraints,
+			GraphName:   graphName,
 		})
 		if err != nil {
-			log.Fatal(err)
+			fatalConstraints(err)
 		}
 
 		fmt.Printf("response: %v\n", resp)
@@ -163,12 +167,13 @@ func createNetworkSliceFunc(mgmtAddr, cbsAddr, netAddr, invAddr, fileName string
 	})
 }
 
-func deleteNetworkSliceFunc(mgmtAddr, invAddr, uuid string) {
+func deleteNetworkSliceFunc(mgmtAddr, invAddr, netAddr, uuid string) {
 	pkg.WithManagement(mgmtAddr, func(c protocol.ManagerClient) error {
 		fmt.Printf("sent request\n")
 		resp, err := c.DeleteSlice(context.TODO(), &protocol.DeleteSliceRequest{
 			InvAddr: invAddr,
 			Uuid:    uuid,
+			NetAddr: netAddr,
 		})
 
 		if err != nil {
@@ -205,8 +210,39 @@ func configureNetworkSliceFunc(mgmtAddr, invAddr, uuid string) {
 			log.Fatal(err)
 		}
 
-		fmt.Printf("Slice: %+v\n", resp)
+		configs := make([]*pkg.NodeConfig, 0)
+		err = json.Unmarshal([]byte(resp.Response), &configs)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, cfg := range configs {
+			fmt.Printf("%s (%s):\n%s\n", cfg.Name, cfg.Mgmt, cfg.Script())
+		}
 
 		return nil
 	})
 }
+
+// fatalConstraints prints what is wrong with each constraint field before
+// exiting, or the error when it is not about constraints.
+func fatalConstraints(err error) {
+	errs := constraint.FromError(err)
+	if len(errs) == 0 {
+		log.Fatal(err)
+	}
+
+	for _, fe := range errs {
+		fmt.Printf("%s\n", fe)
+	}
+	log.Fatal(constraint.ErrInvalid)
+}
+
+// explainFunc prints the constraints a request file compiles to
+func explainFunc(cons []*proto.Constraint) {
+	out, err := constraint.Explain(cons)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(out)
+}
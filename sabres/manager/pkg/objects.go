@@ -19,12 +19,32 @@ type Slice struct {
 	Uuid    string
 	Devices []map[string]string
 	Edges   []map[string]string
+	// GraphVersion is the fingerprint of the network graph snapshot the
+	// slice was solved against
+	GraphVersion string
+	// GraphName is the network graph the slice holds a reservation in
+	GraphName string
+	Version   int64
+}
+
+// SubnetPool records the data plane subnets given to each configured
+// slice, so no two slices are given the same subnets.
+type SubnetPool struct {
+	// Slices maps a slice uuid to its subnets
+	Slices  map[string]SubnetRange
 	Version int64
 }
 
+// SubnetRange is Count subnets from the subnet counter First
+type SubnetRange struct {
+	First int
+	Count int
+}
+
 var (
-	IPPrefix    = "/ipmgmt"
-	SlicePrefix = "/slice"
+	IPPrefix      = "/ipmgmt"
+	SlicePrefix   = "/slice"
+	SubnetPoolKey = "/subnets"
 )
 
 // Required functions for stor
@@ -52,3 +72,9 @@ func (x *Slice) Key() string {
 func (x *Slice) SetVersion(v int64) { x.Version = v }
 func (x *Slice) GetVersion() int64  { return x.Version }
 func (x *Slice) Value() interface{} { return x }
+
+// SubnetPool definitions
+func (x *SubnetPool) Key() string        { return SubnetPoolKey }
+func (x *SubnetPool) SetVersion(v int64) { x.Version = v }
+func (x *SubnetPool) GetVersion() int64  { return x.Version }
+func (x *SubnetPool) Value() interface{} { return x }
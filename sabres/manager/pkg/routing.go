@@ -4,196 +4,196 @@ import (
 	"fmt"
 	"net"
 	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	graph "pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
 )
 
-// IPAssignment will do the heavy lifting for static ip routing
-func IPAssignment(path []string, counter int) (map[string]net.IP, map[string][]net.IPNet, int, error) {
+// DataPlanePrefix is the /16 slice links are addressed from, each link
+// takes a /24 subnet of it.
+var DataPlanePrefix = "10.10"
 
-	// first and last nodes in path will only have a single ip address
-	totalNetworks := len(path) - 1
-
-	ipMap := make(map[string][]net.IP)
-	ipRoute := make(map[string][]net.IPNet)
-
-	for i, n := range path {
-		// last node
-		if i == len(path) {
-		}
-
-		// otherwise look at both nodes
-		thisIP := fmt.Sprintf("10.10.%d.%d/24", counter)
-
-	}
+// LinkAddress is the address of a node on one link of a slice
+type LinkAddress struct {
+	Link   string `json:"link"`
+	Peer   string `json:"peer"`
+	Device string `json:"device"`
+	Addr   string `json:"addr"`
 }
 
-func WalkManager(source, target string, G *graph.Graph) ([]string, error) {
-
-	if source == target {
-		return []string{source}, nil
-	}
-
-	v1 := &graph.Vertex{Name: source}
-	v2 := &graph.Vertex{Name: target}
+// Route is a static route to the subnet of a link the node is not on
+type Route struct {
+	Dst string `json:"dst"`
+	Via string `json:"via"`
+}
 
-	found, _ := G.FindVertex(v1)
-	if !found {
-		return nil, fmt.Errorf("source not in graph: %s\n", source)
-	}
+// NodeConfig is the data plane configuration of a node in a slice
+type NodeConfig struct {
+	Name      string         `json:"name"`
+	Mgmt      string         `json:"mgmt"`
+	Forward   bool           `json:"forward"`
+	Addresses []*LinkAddress `json:"addresses"`
+	Routes    []*Route       `json:"routes"`
+}
 
-	found, _ = G.FindVertex(v2)
-	if !found {
-		return nil, fmt.Errorf("target not in graph: %s\n", source)
+// IPAssignment will do the heavy lifting for static ip routing.  Each link
+// of the tree gets the next subnet from counter, and each node a route to
+// every subnet it is not on through the neighbor towards it.  The counter
+// for the next slice is returned with the configurations.
+func IPAssignment(t *graph.Tree, counter int) (map[string]*NodeConfig, int, error) {
+	configs := make(map[string]*NodeConfig)
+	for _, name := range t.Names() {
+		configs[name] = &NodeConfig{
+			Name:      name,
+			Addresses: make([]*LinkAddress, 0),
+			Routes:    make([]*Route, 0),
+		}
 	}
 
-	path := []string{source}
-
-	only, neigh, err := NeighborWalk("", source, G)
-	if err != nil {
-		return nil, err
+	type link struct {
+		subnet *net.IPNet
+		addrs  map[string]net.IP
 	}
+	links := make(map[*graph.Edge]*link)
 
-	path = append(path, neigh)
-
-	prev := source
-	current := neigh
-
-	for {
-
-		only, neigh, err = NeighborWalk(prev, current, G)
-		if err != nil {
-			return nil, err
-		}
-
-		path = append(path, neigh)
-		if only {
-			return path, nil
+	for _, e := range t.Edges {
+		if counter < 1 || counter > 254 {
+			return nil, counter, fmt.Errorf("out of subnets in %s.0.0/16 at %d", DataPlanePrefix, counter)
 		}
 
-		temp := current
-		current = neigh
-		prev = temp
-
-		// again this shouldnt happen, but because this is linked list it
-		// should also hit this point
-		if current == target {
-			return path, nil
+		_, subnet, err := net.ParseCIDR(fmt.Sprintf("%s.%d.0/24", DataPlanePrefix, counter))
+		if err != nil {
+			return nil, counter, err
 		}
+		counter++
 
-		log.Infof("Path: %v\n", path)
+		names := []string{e.Vertices[0].Name, e.Vertices[1].Name}
+		sort.Strings(names)
 
-		// because this is a linked list, we cant have more edges than nodes
-		// so this will be the trigger
-		if len(path) >= len(G.Vertices) {
-			errMsg := fmt.Errorf("Going infinite: %s", path)
-			log.Errorf("%v\n", errMsg)
-			return nil, errMsg
+		l := &link{subnet: subnet, addrs: make(map[string]net.IP)}
+		for i, name := range names {
+			ip := make(net.IP, len(subnet.IP))
+			copy(ip, subnet.IP)
+			ip[len(ip)-1] = byte(i + 1)
+			l.addrs[name] = ip
+
+			ones, _ := subnet.Mask.Size()
+			configs[name].Addresses = append(configs[name].Addresses, &LinkAddress{
+				Link: e.Name,
+				Peer: names[1-i],
+				Addr: fmt.Sprintf("%s/%d", ip, ones),
+			})
 		}
-
+		links[e] = l
 	}
 
-	return nil, nil
-
-}
-
-// TODO: move to graph lib. assumes single neighbor
-func NeighborWalk(prev, current string, G *graph.Graph) (bool, string, error) {
-
-	only := true
-
-	for _, edge := range G.Edges {
-		if len(edge.Vertices) < 2 {
-			return false, "", fmt.Errorf("edge had less than 2 vertices")
+	for name, cfg := range configs {
+		// eth0 is left for management
+		for i, a := range cfg.Addresses {
+			a.Device = fmt.Sprintf("eth%d", i+1)
 		}
-		vX := edge.Vertices[0]
-		vY := edge.Vertices[1]
+		cfg.Forward = len(cfg.Addresses) > 1
 
-		// if we dont have a previous, we are at an endpoint
-		if prev == "" {
-			if vX.Name == current {
-				return only, vY.Name, nil
-			}
-			if vY.Name == current {
-				return only, vX.Name, nil
+		for _, e := range t.Edges {
+			if _, on := links[e].addrs[name]; on {
+				continue
 			}
-		} else {
-			// otherwise we need to make sure this isnt the same edge
-			// we passed in
-			if vX.Name == current && vY.Name != prev {
-				only = false
-				return only, vY.Name, nil
-			}
-			if vY.Name == current && vX.Name != prev {
-				only = false
-				return only, vX.Name, nil
+
+			p, err := t.Path(name, e.Vertices[0].Name)
+			if err != nil {
+				return nil, counter, err
 			}
+
+			// the first hop is the same for both ends of the link
+			hop := p.Vertices[1].Name
+			cfg.Routes = append(cfg.Routes, &Route{
+				Dst: links[e].subnet.String(),
+				Via: links[p.Edges[0]].addrs[hop].String(),
+			})
 		}
 	}
 
-	return only, "", nil
+	return configs, counter, nil
 }
 
-func CreatePath(edgeMap []map[string]string) ([]string, error) {
-
-	counter := map[string]int{}
+// Allocate returns the first subnet counter of a slice with count links,
+// for IPAssignment.  A slice keeps the subnets it was given while they are
+// enough, otherwise it is given the first count subnets no other slice
+// has.
+func (p *SubnetPool) Allocate(slice string, count int) (int, error) {
+	if p.Slices == nil {
+		p.Slices = make(map[string]SubnetRange)
+	}
+	if r, ok := p.Slices[slice]; ok && r.Count >= count {
+		return r.First, nil
+	}
+	delete(p.Slices, slice)
 
-	G := &graph.Graph{Name: "path-select"}
+	used := make([]SubnetRange, 0, len(p.Slices))
+	for _, r := range p.Slices {
+		used = append(used, r)
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].First < used[j].First })
 
-	for _, edge := range edgeMap {
-		v1 := &graph.Vertex{Name: edge["src"]}
-		v2 := &graph.Vertex{Name: edge["dst"]}
-		names := []string{v1.Name, v2.Name}
-		sort.Strings(names)
-		e1 := &graph.Edge{
-			Name:     fmt.Sprintf("%s-%s", names[0], names[1]),
-			Vertices: []*graph.Vertex{v1, v2},
+	first := 1
+	for _, r := range used {
+		if first+count <= r.First {
+			break
 		}
-
-		err := G.AddEdgeObj(e1)
-		if err != nil {
-			log.Errorf("Failed to add edge 1: %v\n", err)
-			return nil, err
+		if r.First+r.Count > first {
+			first = r.First + r.Count
 		}
+	}
+	if first+count-1 > 254 {
+		return 0, fmt.Errorf("out of subnets in %s.0.0/16 for %d links of slice %s", DataPlanePrefix, count, slice)
+	}
 
-		_, ok := counter[v1.Name]
-		if ok {
-			counter[v1.Name]++
-		} else {
-			counter[v1.Name] = 1
-		}
+	p.Slices[slice] = SubnetRange{First: first, Count: count}
+	return first, nil
+}
 
-		_, ok2 := counter[v2.Name]
-		if ok2 {
-			counter[v2.Name]++
-		} else {
-			counter[v2.Name] = 1
-		}
+// Release returns the subnets of a slice to the pool, false when it had
+// none.
+func (p *SubnetPool) Release(slice string) bool {
+	_, ok := p.Slices[slice]
+	delete(p.Slices, slice)
+	return ok
+}
 
-	}
+// Script returns the shell commands that configure the node
+func (c *NodeConfig) Script() string {
+	var b strings.Builder
 
-	// now we should know a source sink from counter map
-	var s, t string
-	set := false
-	for k, v := range counter {
-		if v == 1 {
-			if !set {
-				s = k
-				set = true
-			} else {
-				t = k
-			}
-		}
+	fmt.Fprintf(&b, "#!/bin/sh\n# data plane of %s\nset -e\n", c.Name)
+	for _, a := range c.Addresses {
+		fmt.Fprintf(&b, "# link %s to %s\n", a.Link, a.Peer)
+		fmt.Fprintf(&b, "ip addr add %s dev %s\n", a.Addr, a.Device)
+		fmt.Fprintf(&b, "ip link set %s up\n", a.Device)
+	}
+	if c.Forward {
+		fmt.Fprintf(&b, "sysctl -w net.ipv4.ip_forward=1\n")
+	}
+	for _, r := range c.Routes {
+		fmt.Fprintf(&b, "ip route add %s via %s\n", r.Dst, r.Via)
 	}
 
-	log.Infof("found: %s <--> %s\n", s, t)
+	return b.String()
+}
 
-	// then we walk the graph
-	path, err := WalkManager(s, t, G)
+// CreatePath returns the vertices of a slice in order from one end to the
+// other, the slice must be a path.  Use ExtractTopology for other shapes.
+func CreatePath(edgeMap []map[string]string) ([]string, error) {
+	t, err := ExtractTopology(edgeMap)
 	if err != nil {
 		return nil, err
 	}
 
-	return path, nil
+	if t.Kind != TopologyPath {
+		return nil, fmt.Errorf("%w: it is a %s", ErrNotPath, t)
+	}
+
+	log.Infof("found: %s <--> %s\n", t.Hops[0], t.Hops[len(t.Hops)-1])
+
+	return t.Hops, nil
 }
@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -46,3 +47,210 @@ func TestGraphToPath(t *testing.T) {
 
 	log.Infof("path: %v\n", path)
 }
+
+// multiSiteSlice joins sites a, b and c through the switches s1 and s2,
+// with a loop between the switches and a dead end at s3.
+func multiSiteSlice() *Slice {
+	return &Slice{
+		Name: "multi-site",
+		Devices: []map[string]string{
+			{"node": "a", "cpu": "8"},
+			{"node": "b", "cpu": "8"},
+			{"node": "c", "cpu": "8"},
+		},
+		Edges: []map[string]string{
+			{"src": "a", "dst": "s1", "cost": "10"},
+			{"src": "s1", "dst": "b", "cost": "10"},
+			{"src": "s1", "dst": "s2", "cost": "10"},
+			{"src": "s2", "dst": "s1", "cost": "10"},
+			{"src": "b", "dst": "s2", "cost": "50"},
+			{"src": "s2", "dst": "c", "cost": "10"},
+			{"src": "s2", "dst": "s3", "cost": "10"},
+		},
+	}
+}
+
+func TestSliceTree(t *testing.T) {
+	tree, err := SliceTree(multiSiteSlice())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.ElementsMatch(t, []string{"a", "b", "c", "s1", "s2"}, tree.Names())
+	assert.Equal(t, []string{"a", "b", "c"}, tree.Leaves())
+	assert.Equal(t, 40.0, tree.Cost)
+
+	// a line is a tree with two leaves
+	contents, err := ioutil.ReadFile("./demo-test-slice.json")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	obj := &Slice{}
+	err = json.Unmarshal(contents, obj)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tree, err = SliceTree(obj)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 7, len(tree.Vertices))
+	assert.Equal(t, []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"}, tree.Leaves())
+}
+
+func TestIPAssignment(t *testing.T) {
+	tree, err := SliceTree(multiSiteSlice())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	configs, next, err := IPAssignment(tree, 1)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 5, next, "one subnet per link")
+
+	// a -- s1 is the first link
+	a := configs["a"]
+	assert.False(t, a.Forward)
+	assert.Equal(t, []*LinkAddress{{Link: "a-s1", Peer: "s1", Device: "eth1", Addr: "10.10.1.1/24"}}, a.Addresses)
+	assert.Equal(t, 3, len(a.Routes), "a route to every other link")
+	for _, r := range a.Routes {
+		assert.Equal(t, "10.10.1.2", r.Via)
+	}
+
+	s2 := configs["s2"]
+	assert.True(t, s2.Forward)
+	assert.Equal(t, 2, len(s2.Addresses))
+	assert.Equal(t, 2, len(s2.Routes))
+
+	// c reaches a's link through s2, and s2 reaches it through s1
+	c := configs["c"]
+	var via string
+	for _, r := range c.Routes {
+		if r.Dst == "10.10.1.0/24" {
+			via = r.Via
+		}
+	}
+	assert.Equal(t, s2.Addresses[1].Addr, via+"/24")
+
+	script := s2.Script()
+	assert.Contains(t, script, "sysctl -w net.ipv4.ip_forward=1\n")
+	assert.Contains(t, script, "ip route add 10.10.1.0/24 via ")
+
+	_, _, err = IPAssignment(tree, 253)
+	assert.NotNil(t, err, "runs out of subnets")
+}
+
+func TestSubnetPool(t *testing.T) {
+	tree, err := SliceTree(multiSiteSlice())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// two slices on the same sites are given their own subnets
+	pool := &SubnetPool{}
+	subnets := make(map[string]string)
+	for _, slice := range []string{"one", "two"} {
+		first, err := pool.Allocate(slice, len(tree.Edges))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		configs, _, err := IPAssignment(tree, first)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		for _, cfg := range configs {
+			for _, a := range cfg.Addresses {
+				other, ok := subnets[a.Addr]
+				assert.False(t, ok && other != slice, "%s of %s is also in %s", a.Addr, slice, other)
+				subnets[a.Addr] = slice
+			}
+		}
+	}
+	assert.Equal(t, 2*2*len(tree.Edges), len(subnets))
+
+	first, err := pool.Allocate("two", len(tree.Edges))
+	assert.Nil(t, err)
+	assert.Equal(t, 1+len(tree.Edges), first, "a slice keeps its subnets")
+
+	// the subnets of a released slice are given out again
+	assert.True(t, pool.Release("one"))
+	assert.False(t, pool.Release("one"))
+	first, err = pool.Allocate("three", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, first)
+	first, err = pool.Allocate("four", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 1+2*len(tree.Edges), first, "too few free between three and two")
+
+	_, err = pool.Allocate("five", 254)
+	assert.NotNil(t, err, "runs out of subnets")
+}
+
+func slicePairs(pairs ...string) []map[string]string {
+	edges := make([]map[string]string, 0)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		edges = append(edges, map[string]string{"src": pairs[i], "dst": pairs[i+1]})
+	}
+	return edges
+}
+
+func TestExtractTopology(t *testing.T) {
+	// a path given backwards and with a link in both directions
+	topo, err := ExtractTopology(slicePairs("c", "d", "b", "c", "c", "b", "a", "b"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, TopologyPath, topo.Kind)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, topo.Hops)
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}}, topo.Branches)
+	assert.Equal(t, 0, len(topo.Loops))
+
+	// a star with one long arm
+	topo, err = ExtractTopology(slicePairs("s", "a", "s", "b", "s", "c", "c", "d"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, TopologyTree, topo.Kind)
+	assert.Equal(t, 0, len(topo.Hops))
+	assert.ElementsMatch(t, [][]string{{"a", "s"}, {"b", "s"}, {"d", "c", "s"}}, topo.Branches)
+	assert.Equal(t, 0, len(topo.Loops))
+
+	topo, err = ExtractTopology(slicePairs("a", "b", "b", "c", "c", "d", "d", "a"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, TopologyRing, topo.Kind)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, topo.Hops)
+	assert.Equal(t, 1, len(topo.Loops))
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, topo.Loops[0])
+	assert.Equal(t, "ring a -- b -- c -- d -- a", topo.String())
+
+	// two triangles sharing the b-c link, with a tail at e
+	topo, err = ExtractTopology(slicePairs("a", "b", "b", "c", "c", "a", "b", "d", "d", "c", "d", "e"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, TopologyMesh, topo.Kind)
+	assert.Equal(t, 2, len(topo.Loops))
+	assert.Equal(t, []string{"b", "a", "c"}, topo.Loops[0])
+	assert.Contains(t, topo.Branches, []string{"d", "e"})
+	assert.Equal(t, 0, len(topo.Hops))
+}
+
+func TestExtractTopologyErrors(t *testing.T) {
+	_, err := ExtractTopology(nil)
+	assert.True(t, errors.Is(err, ErrEmptySlice))
+
+	_, err = ExtractTopology(slicePairs("a", "b", "c", "d"))
+	assert.True(t, errors.Is(err, ErrDisconnectedSlice))
+	assert.Contains(t, err.Error(), "[a b] [c d]")
+
+	_, err = ExtractTopology([]map[string]string{{"src": "a"}})
+	assert.NotNil(t, err)
+
+	_, err = CreatePath(slicePairs("s", "a", "s", "b", "s", "c"))
+	assert.True(t, errors.Is(err, ErrNotPath))
+}
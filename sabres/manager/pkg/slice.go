@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"fmt"
+
+	graph "pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+// SliceGraph builds the graph of the edges cbs returned for a slice.  The
+// edge properties other than src and dst are kept, so cost can weigh them.
+func SliceGraph(s *Slice) (*graph.Graph, error) {
+	G := &graph.Graph{Name: s.Name}
+
+	for _, edge := range s.Edges {
+		src, dst := edge["src"], edge["dst"]
+		if src == "" || dst == "" {
+			return nil, fmt.Errorf("slice edge without src and dst: %v", edge)
+		}
+
+		props := make(map[string]string)
+		for k, v := range edge {
+			if k != "src" && k != "dst" {
+				props[k] = v
+			}
+		}
+
+		v1, v2 := &graph.Vertex{Name: src}, &graph.Vertex{Name: dst}
+
+		// cbs can list a link in both directions
+		if _, found := G.FindEdge(&graph.Edge{Vertices: []*graph.Vertex{v1, v2}}); found {
+			continue
+		}
+
+		_, err := G.AddEdge(v1, v2, props)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return G, nil
+}
+
+// SliceTerminals returns the nodes the slice must connect: the devices cbs
+// placed, or the ends of the edges when there are none.
+func SliceTerminals(s *Slice, G *graph.Graph) []string {
+	terms := make([]string, 0)
+	for _, dev := range s.Devices {
+		if node, ok := dev["node"]; ok && node != "" {
+			terms = append(terms, node)
+		}
+	}
+	if len(terms) > 0 {
+		return terms
+	}
+
+	for _, v := range G.Vertices {
+		if G.Degree(v.Name) == 1 {
+			terms = append(terms, v.Name)
+		}
+	}
+	return terms
+}
+
+// SliceTree returns the tree joining the terminals of a slice, which may
+// have any number of sites.  Edges cbs returned that are not needed to join
+// them, loops and dead ends, are dropped.
+func SliceTree(s *Slice) (*graph.Tree, error) {
+	G, err := SliceGraph(s)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := SliceTerminals(s, G)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("slice %s has no endpoints", s.Uuid)
+	}
+
+	return G.SteinerTree(terms, &graph.PathOptions{Weight: sliceCost})
+}
+
+// sliceCost weighs slice edges by the cost cbs gave them, a hop if unset.
+func sliceCost(e *graph.Edge) (float64, error) {
+	if _, ok := e.Properties["cost"]; !ok {
+		return 1, nil
+	}
+
+	v, err := e.Attr("cost")
+	if err != nil {
+		return 0, err
+	}
+	if !v.Numeric() {
+		return 0, fmt.Errorf("edge %s: cost %s is not a number", e.Name, v)
+	}
+
+	return v.Num, nil
+}
@@ -0,0 +1,245 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrEmptySlice        = errors.New("slice has no edges")
+	ErrDisconnectedSlice = errors.New("slice edges are disconnected")
+	ErrNotPath           = errors.New("slice is not a path")
+)
+
+// TopologyKind is the shape of the edges of a slice
+type TopologyKind string
+
+const (
+	// TopologyPath is a line, every vertex has at most two neighbors
+	TopologyPath TopologyKind = "path"
+	// TopologyTree has no loops and a vertex with three or more neighbors
+	TopologyTree TopologyKind = "tree"
+	// TopologyRing is a single loop, every vertex has two neighbors
+	TopologyRing TopologyKind = "ring"
+	// TopologyMesh is anything else with a loop
+	TopologyMesh TopologyKind = "mesh"
+)
+
+// Topology is the structure of the edge set cbs returned for a slice.
+type Topology struct {
+	Kind TopologyKind `json:"kind"`
+	// Vertices are the vertex names in name order
+	Vertices []string `json:"vertices"`
+	// Hops are the vertices in order along a path or around a ring
+	Hops []string `json:"hops"`
+	// Branches are the runs of vertices between the ends and junctions (any
+	// vertex without exactly two neighbors), a path is a single branch
+	Branches [][]string `json:"branches"`
+	// Loops are a cycle basis, each loop lists its vertices in order
+	Loops [][]string `json:"loops"`
+}
+
+// ExtractTopology classifies the src, dst edges cbs returned for a slice as
+// a path, tree, ring or mesh.  An edge given in both directions is a single
+// link.
+func ExtractTopology(edgeMap []map[string]string) (*Topology, error) {
+	G, err := SliceGraph(&Slice{Edges: edgeMap})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(G.Edges) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	comps := G.ConnectedComponents()
+	if len(comps) > 1 {
+		parts := make([]string, 0, len(comps))
+		for _, c := range comps {
+			parts = append(parts, fmt.Sprintf("[%s]", strings.Join(c, " ")))
+		}
+		return nil, fmt.Errorf("%w: %d parts %s", ErrDisconnectedSlice, len(comps), strings.Join(parts, " "))
+	}
+
+	t := &Topology{
+		Vertices: make([]string, 0, len(G.Vertices)),
+		Hops:     make([]string, 0),
+		Branches: make([][]string, 0),
+		Loops:    make([][]string, 0),
+	}
+	for _, v := range G.Vertices {
+		t.Vertices = append(t.Vertices, v.Name)
+	}
+	sort.Strings(t.Vertices)
+
+	neighbors := make(map[string][]string, len(t.Vertices))
+	maxDegree := 0
+	for _, name := range t.Vertices {
+		for _, e := range G.IncidentEdges(name) {
+			neighbors[name] = append(neighbors[name], e.Other(name))
+		}
+		sort.Strings(neighbors[name])
+		if len(neighbors[name]) > maxDegree {
+			maxDegree = len(neighbors[name])
+		}
+	}
+
+	n, m := len(t.Vertices), len(G.Edges)
+	switch {
+	case m == n-1 && maxDegree <= 2:
+		t.Kind = TopologyPath
+	case m == n-1:
+		t.Kind = TopologyTree
+	case m == n && maxDegree == 2:
+		t.Kind = TopologyRing
+	default:
+		t.Kind = TopologyMesh
+	}
+
+	switch t.Kind {
+	case TopologyPath:
+		// start from the first end by name so the order is stable
+		for _, name := range t.Vertices {
+			if len(neighbors[name]) == 1 {
+				t.Hops = walk(name, "", neighbors)
+				break
+			}
+		}
+	case TopologyRing:
+		t.Hops = walk(t.Vertices[0], "", neighbors)
+	}
+
+	t.Branches = branches(t.Vertices, neighbors)
+	t.Loops = loops(t.Vertices, neighbors)
+
+	return t, nil
+}
+
+// walk follows vertices with two neighbors from start, leaving it towards
+// the first neighbor that is not prev, until an end or back at start.
+func walk(start, prev string, neighbors map[string][]string) []string {
+	hops := []string{start}
+	cur := start
+	for {
+		next := ""
+		for _, nb := range neighbors[cur] {
+			if nb != prev {
+				next = nb
+				break
+			}
+		}
+		if next == "" || next == start {
+			return hops
+		}
+		hops = append(hops, next)
+		if len(neighbors[next]) != 2 {
+			return hops
+		}
+		prev, cur = cur, next
+	}
+}
+
+// branches splits the slice into runs between vertices that do not have
+// exactly two neighbors.
+func branches(vertices []string, neighbors map[string][]string) [][]string {
+	out := make([][]string, 0)
+	used := make(map[[2]string]bool)
+	link := func(a, b string) [2]string {
+		if b < a {
+			a, b = b, a
+		}
+		return [2]string{a, b}
+	}
+
+	for _, name := range vertices {
+		if len(neighbors[name]) == 2 {
+			continue
+		}
+		for _, nb := range neighbors[name] {
+			if used[link(name, nb)] {
+				continue
+			}
+
+			run := []string{name, nb}
+			used[link(name, nb)] = true
+			for prev, cur := name, nb; len(neighbors[cur]) == 2; {
+				next := neighbors[cur][0]
+				if next == prev {
+					next = neighbors[cur][1]
+				}
+				used[link(cur, next)] = true
+				run = append(run, next)
+				prev, cur = cur, next
+			}
+			out = append(out, run)
+		}
+	}
+
+	return out
+}
+
+// loops returns the fundamental cycles of a breadth first spanning tree,
+// one for each link that is not in the tree.
+func loops(vertices []string, neighbors map[string][]string) [][]string {
+	out := make([][]string, 0)
+	if len(vertices) == 0 {
+		return out
+	}
+
+	parent := map[string]string{vertices[0]: ""}
+	depth := map[string]int{vertices[0]: 0}
+	order := []string{vertices[0]}
+	for i := 0; i < len(order); i++ {
+		cur := order[i]
+		for _, nb := range neighbors[cur] {
+			if _, seen := parent[nb]; !seen {
+				parent[nb] = cur
+				depth[nb] = depth[cur] + 1
+				order = append(order, nb)
+			}
+		}
+	}
+
+	for _, u := range order {
+		for _, v := range neighbors[u] {
+			// each link not in the tree once
+			if v < u || parent[u] == v || parent[v] == u {
+				continue
+			}
+
+			left, right := []string{u}, []string{v}
+			a, b := u, v
+			for a != b {
+				if depth[a] >= depth[b] {
+					a = parent[a]
+					left = append(left, a)
+				} else {
+					b = parent[b]
+					right = append(right, b)
+				}
+			}
+
+			// u up to the common ancestor and back down to v
+			loop := append([]string{}, left...)
+			for i := len(right) - 2; i >= 0; i-- {
+				loop = append(loop, right[i])
+			}
+			out = append(out, loop)
+		}
+	}
+
+	return out
+}
+
+// String describes the topology, a path or ring by its hops
+func (t *Topology) String() string {
+	switch t.Kind {
+	case TopologyPath:
+		return fmt.Sprintf("path %s", strings.Join(t.Hops, " -- "))
+	case TopologyRing:
+		return fmt.Sprintf("ring %s -- %s", strings.Join(t.Hops, " -- "), t.Hops[0])
+	}
+	return fmt.Sprintf("%s of %d vertices, %d branches, %d loops", t.Kind, len(t.Vertices), len(t.Branches), len(t.Loops))
+}
@@ -16,11 +16,14 @@ import (
 	"gitlab.com/mergetb/tech/stor"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	cbspkg "pulwar.isi.edu/sabres/cbs/cbs/service/pkg"
 	inv "pulwar.isi.edu/sabres/orchestrator/inventory/protocol"
 	config "pulwar.isi.edu/sabres/orchestrator/pkg"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/manager/pkg"
 	proto "pulwar.isi.edu/sabres/orchestrator/sabres/manager/protocol"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
 	netpkg "pulwar.isi.edu/sabres/orchestrator/sabres/network/pkg"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
 )
@@ -63,11 +66,22 @@ func (s *ManagerServer) CreateSlice(ctx context.Context, req *proto.CreateSliceR
 
 	log.Infof("constraints: %+v\n", constraints)
 
-	// TODO: check validity
+	// the network service checks them against the graph as well
+	_, err := constraint.ParseAll(constraints)
+	if err != nil {
+		log.Errorf("%v\n", err)
+		return nil, err
+	}
 
 	var cbsOut cbspkg.JsonCBSOut
+	var graphVersion string
 
-	err := netpkg.WithNetwork(netaddr, func(c protocol.NetworkClient) error {
+	// the slice resources are held under its uuid while the slice is
+	// stored, then committed until it is deleted.  The hold runs out by
+	// itself if we stop before the commit.
+	sliceUuid := uuid.New().String()
+
+	err = netpkg.WithNetwork(netaddr, func(c protocol.NetworkClient) error {
 		cbsAddrSplit := strings.Split(cbsaddr, ":")
 		if len(cbsAddrSplit) != 2 {
 			return fmt.Errorf("invalid network address: %s", cbsaddr)
@@ -82,14 +96,19 @@ func (s *ManagerServer) CreateSlice(ctx context.Context, req *proto.CreateSliceR
 
 		resp, err := c.RequestSolution(context.TODO(), &protocol.SolveRequest{
 			Constraints: constraints,
+			GraphName:   req.GraphName,
+			Reserve:     sliceUuid,
+			HoldTtl:     int64(netpkg.DefaultHoldTTL / time.Second),
 		})
 		if err != nil {
 			return err
 		}
 
 		strCBSOut := resp.Response
+		graphVersion = resp.GraphVersion
 
 		log.Infof("solution response from cbs: %v\n", strCBSOut)
+		log.Infof("solved against graph version: %s\n", graphVersion)
 
 		err = json.Unmarshal([]byte(strCBSOut), &cbsOut)
 		if err != nil {
@@ -102,13 +121,13 @@ func (s *ManagerServer) CreateSlice(ctx context.Context, req *proto.CreateSliceR
 		return nil, err
 	}
 
-	sliceUuid := uuid.New().String()
-
 	sliceObj := &pkg.Slice{
-		Name:    sliceUuid,
-		Uuid:    sliceUuid,
-		Devices: cbsOut.Nodes,
-		Edges:   cbsOut.Edges,
+		Name:         sliceUuid,
+		Uuid:         sliceUuid,
+		Devices:      cbsOut.Nodes,
+		Edges:        cbsOut.Edges,
+		GraphVersion: graphVersion,
+		GraphName:    req.GraphName,
 	}
 
 	log.Infof("uuid for solution: %s\n", sliceUuid)
@@ -118,6 +137,28 @@ func (s *ManagerServer) CreateSlice(ctx context.Context, req *proto.CreateSliceR
 
 	err = stor.WriteObjects(objs, true)
 	if err != nil {
+		rerr := releaseSlice(netaddr, sliceObj)
+		if rerr != nil {
+			log.Errorf("release reservation of unstored slice %s: %v\n", sliceUuid, rerr)
+		}
+		return nil, err
+	}
+
+	err = netpkg.WithNetwork(netaddr, func(c protocol.NetworkClient) error {
+		_, err := c.CommitReservation(context.TODO(), &protocol.CommitReservationRequest{
+			GraphName: req.GraphName,
+			Name:      sliceUuid,
+		})
+		return err
+	})
+	if err != nil {
+		// the hold ran out or was taken away, the slice has no resources
+		log.Errorf("commit reservation of slice %s: %v\n", sliceUuid, err)
+
+		derr := stor.DeleteObjects(objs)
+		if derr != nil {
+			log.Errorf("delete uncommitted slice %s: %v\n", sliceUuid, derr)
+		}
 		return nil, err
 	}
 
@@ -131,13 +172,120 @@ func (s *ManagerServer) DeleteSlice(ctx context.Context, req *proto.DeleteSliceR
 		return nil, fmt.Errorf("%s", errMsg)
 	}
 
-	// inventory
+	_, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		return nil, err
+	}
 
-	// remove uuid from slice
+	netaddr := fmt.Sprintf("localhost:%d", netpkg.DefaultNetworkPort)
+	if req.NetAddr != "" {
+		netaddr = req.NetAddr
+	}
+
+	so := &pkg.Slice{Uuid: req.Uuid}
+	err = stor.Read(so)
+	if err != nil {
+		return nil, err
+	}
+
+	// credit the resources of the slice back to the network graph, the
+	// slice is kept when that fails so the delete can be tried again
+	err = releaseSlice(netaddr, so)
+	if err != nil {
+		return nil, fmt.Errorf("release reservation of slice %s: %w", so.Uuid, err)
+	}
+
+	err = releaseSubnets(so.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("release subnets of slice %s: %w", so.Uuid, err)
+	}
+
+	err = stor.Delete(so)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("deleted slice %s\n", so.Uuid)
 
 	return &proto.DeleteSliceResponse{}, nil
 }
 
+// releaseSlice releases the reservation of a slice.  Slices made before
+// there was a ledger, or on a graph since deleted, have none to release.
+func releaseSlice(netaddr string, so *pkg.Slice) error {
+	err := netpkg.WithNetwork(netaddr, func(c protocol.NetworkClient) error {
+		_, err := c.ReleaseReservation(context.TODO(), &protocol.ReleaseReservationRequest{
+			GraphName: so.GraphName,
+			Name:      so.Uuid,
+		})
+		return err
+	})
+	if status.Code(err) == codes.NotFound {
+		log.Warnf("slice %s has no reservation: %v\n", so.Uuid, err)
+		return nil
+	}
+	return err
+}
+
+// allocateSubnets gives a slice count data plane subnets in the stored
+// pool, or the ones it already has, and returns the first.
+func allocateSubnets(slice string, count int) (int, error) {
+	var err error
+	for try := 0; try < netpkg.StoreRetries; try++ {
+		pool := &pkg.SubnetPool{}
+		err = stor.ReadNew(pool)
+		if err != nil {
+			return 0, err
+		}
+
+		var first int
+		first, err = pool.Allocate(slice, count)
+		if err != nil {
+			return 0, err
+		}
+
+		err = stor.Write(pool, true)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("subnet pool changed during allocation, retrying: %v\n", err)
+				continue
+			}
+			return 0, err
+		}
+		return first, nil
+	}
+
+	return 0, fmt.Errorf("subnet allocation failed after %d tries: %w", netpkg.StoreRetries, err)
+}
+
+// releaseSubnets returns the subnets of a slice to the stored pool
+func releaseSubnets(slice string) error {
+	var err error
+	for try := 0; try < netpkg.StoreRetries; try++ {
+		pool := &pkg.SubnetPool{}
+		err = stor.ReadNew(pool)
+		if err != nil {
+			return err
+		}
+
+		if !pool.Release(slice) {
+			return nil
+		}
+
+		err = stor.Write(pool, true)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("subnet pool changed during release, retrying: %v\n", err)
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+
+	return fmt.Errorf("subnet release failed after %d tries: %w", netpkg.StoreRetries, err)
+}
+
 func (s *ManagerServer) ShowSlice(ctx context.Context, req *proto.ShowSliceRequest) (*proto.ShowSliceResponse, error) {
 	if req == nil {
 		errMsg := fmt.Sprintf("ShowSlice: Nil Request")
@@ -221,6 +369,8 @@ func (s *ManagerServer) ConfigureSlice(ctx context.Context, req *proto.Configure
 		return nil, err
 	}
 
+	log.Infof("slice %s was solved against graph version %s\n", so.Uuid, so.GraphVersion)
+
 	if len(so.Devices) <= 0 {
 		errMsg := fmt.Errorf("There are no devices in this slice")
 		log.Errorf("%v\n", errMsg)
@@ -276,24 +426,56 @@ func (s *ManagerServer) ConfigureSlice(ctx context.Context, req *proto.Configure
 
 	log.Infof("all our edge management ips found: %v\n", mgmt)
 
-	// create the route path
-	path, err := pkg.CreatePath(so.Edges)
+	topo, err := pkg.ExtractTopology(so.Edges)
 	if err != nil {
-		log.Errorf("create path failure: %v\n", err)
+		log.Errorf("slice topology failure: %v\n", err)
 		return nil, err
 	}
 
-	log.Infof("path through slice: %v\n", path)
+	log.Infof("slice topology: %s\n", topo)
 
-	// assign data plane network ips
+	// the slice can join any number of sites, so route over the tree that
+	// joins them
+	tree, err := pkg.SliceTree(so)
+	if err != nil {
+		log.Errorf("slice tree failure: %v\n", err)
+		return nil, err
+	}
+
+	log.Infof("tree through slice: %v, endpoints: %v\n", tree.Names(), tree.Terminals)
+
+	// assign data plane network ips from the subnets of the slice
+	first, err := allocateSubnets(so.Uuid, len(tree.Edges))
+	if err != nil {
+		log.Errorf("subnet allocation failure: %v\n", err)
+		return nil, err
+	}
+
+	configs, _, err := pkg.IPAssignment(tree, first)
+	if err != nil {
+		log.Errorf("ip assignment failure: %v\n", err)
+		return nil, err
+	}
 
 	// generate configuration script
+	out := make([]*pkg.NodeConfig, 0, len(configs))
+	for _, name := range tree.Names() {
+		cfg := configs[name]
+		cfg.Mgmt = mgmt[name]
+		log.Infof("configuration for %s (%s):\n%s", name, cfg.Mgmt, cfg.Script())
+		out = append(out, cfg)
+	}
 
 	// run configuration script
 
 	// save to inventory (?)
 
-	return &proto.ConfigureSliceResponse{}, nil
+	jsonData, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ConfigureSliceResponse{Response: string(jsonData)}, nil
 }
 
 func main() {
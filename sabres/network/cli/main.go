@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/network/pkg"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
 )
@@ -18,6 +24,7 @@ var (
 	clientServer string
 	clientPort   int
 	addr         string
+	graphName    string
 )
 
 func main() {
@@ -31,6 +38,8 @@ func main() {
 		&clientServer, "server", "s", "localhost", "network service address to use")
 	root.PersistentFlags().IntVarP(
 		&clientPort, "port", "p", pkg.DefaultNetworkPort, "network service port to use")
+	root.PersistentFlags().StringVarP(
+		&graphName, "graph", "g", "", "named graph to use, the default graph if not set")
 
 	addr = fmt.Sprintf("%s:%d", clientServer, clientPort)
 
@@ -44,16 +53,98 @@ func main() {
 	}
 	root.AddCommand(createNetworkItem)
 
+	getReq := &protocol.GetGraphRequest{}
+
 	getNetworkItem := &cobra.Command{
 		Use:   "get",
 		Short: "get returns the raw graph",
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			getNetworkItemFunc()
+			getNetworkItemFunc(getReq)
 		},
 	}
+	getNetworkItem.Flags().StringVarP(&getReq.Format, "format", "f", "", "graph format: json, yaml, graphml, gml or dot")
+	getNetworkItem.Flags().StringVar(&getReq.VertexFilter, "vertex-filter", "", "only return vertices matching this filter, e.g. 'cpu >= 8 && aesni'")
+	getNetworkItem.Flags().StringVar(&getReq.EdgeFilter, "edge-filter", "", "only return edges matching this filter, e.g. 'bw >= 1Gbps && selector in (netA, netB)'")
+	getNetworkItem.Flags().StringVar(&getReq.Snapshot, "snapshot", "", "return a stored snapshot by name or fingerprint instead of the working graph")
+	getNetworkItem.Flags().BoolVar(&getReq.Residual, "residual", false, "return the capacity left after reservations")
 	root.AddCommand(getNetworkItem)
 
+	var loadFormat string
+
+	loadNetworkItem := &cobra.Command{
+		Use:   "load <file>",
+		Short: "Load a graph from a file instead of inventory",
+		Long:  "Load a graph from a dot, json, yaml, graphml or gml file, the format is taken from the file extension unless --format is given",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			loadNetworkItemFunc(args[0], loadFormat)
+		},
+	}
+	loadNetworkItem.Flags().StringVarP(&loadFormat, "format", "f", "", "graph format: dot, json, yaml, graphml or gml")
+	root.AddCommand(loadNetworkItem)
+
+	var diffFormat string
+
+	diffNetworkItem := &cobra.Command{
+		Use:   "diff [file]",
+		Short: "Show the changes from the existing graph to inventory, or to a graph file",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			fi := ""
+			if len(args) > 0 {
+				fi = args[0]
+			}
+			diffNetworkItemFunc(fi, diffFormat)
+		},
+	}
+	diffNetworkItem.Flags().StringVarP(&diffFormat, "format", "f", "", "graph file format: dot, json, yaml, graphml or gml")
+	root.AddCommand(diffNetworkItem)
+
+	renderOpts := &protocol.RenderGraphRequest{}
+
+	renderNetworkItem := &cobra.Command{
+		Use:   "render <output-file>",
+		Short: "Render the graph to an svg, png or dot file",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			renderNetworkItemFunc(args[0], renderOpts)
+		},
+	}
+	renderNetworkItem.Flags().StringVarP(&renderOpts.Format, "format", "f", "", "image format: svg, png or dot, taken from the file extension if not set")
+	renderNetworkItem.Flags().StringVar(&renderOpts.ColorBy, "color-by", "selector", "edge property that picks the edge colour")
+	renderNetworkItem.Flags().StringVar(&renderOpts.WidthBy, "width-by", "bw", "numeric edge property that scales the edge width")
+	renderNetworkItem.Flags().StringSliceVar(&renderOpts.VertexLabels, "vertex-labels", []string{"cpu", "mem"}, "vertex properties to show")
+	renderNetworkItem.Flags().StringSliceVar(&renderOpts.EdgeLabels, "edge-labels", nil, "edge properties to show, uuid and bw if not set")
+	renderNetworkItem.Flags().StringSliceVar(&renderOpts.Highlight, "highlight", nil, "vertex names and edge uuids to highlight")
+	root.AddCommand(renderNetworkItem)
+
+	snapshot := &cobra.Command{
+		Use:   "snapshot [name]",
+		Short: "Store the graph by its fingerprint, optionally under a name",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			snapshotFunc(name)
+		},
+	}
+	root.AddCommand(snapshot)
+
+	var initial bool
+	watch := &cobra.Command{
+		Use:   "watch",
+		Short: "Print the changes to the graph as they are made",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			watchFunc(initial)
+		},
+	}
+	watch.Flags().BoolVar(&initial, "initial", false, "print the whole graph as added first")
+	root.AddCommand(watch)
+
 	delNetworkItem := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete the existing graph",
@@ -74,14 +165,23 @@ func main() {
 	}
 	root.AddCommand(showConfig)
 
+	solveReq := &protocol.SolveRequest{}
+
+	var explain bool
 	solve := &cobra.Command{
 		Use:   "solve <file>",
-		Short: "solve the constraints given network topology",
+		Short: "solve the constraints given network topology, a json or .sabres file",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			solveFunc(args[0])
+			solveFunc(args[0], solveReq, explain)
 		},
 	}
+	solve.Flags().StringVar(&solveReq.VertexFilter, "vertex-filter", "", "only solve over vertices matching this filter")
+	solve.Flags().StringVar(&solveReq.EdgeFilter, "edge-filter", "", "only solve over edges matching this filter")
+	solve.Flags().StringVar(&solveReq.Solver, "solver", "", "cbs or native, cbs falling back to native if not set")
+	solve.Flags().BoolVar(&explain, "explain", false, "print the compiled constraints instead of solving")
+	solve.Flags().StringVar(&solveReq.Reserve, "reserve", "", "reserve the resources of the solution under this name")
+	solve.Flags().Int64Var(&solveReq.HoldTtl, "hold", 0, "with --reserve, hold the reservation this many seconds until it is committed")
 	root.AddCommand(solve)
 
 	setHost := &cobra.Command{
@@ -94,18 +194,162 @@ func main() {
 	}
 	root.AddCommand(setHost)
 
+	removeCmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a vertex or edge from the existing graph",
+	}
+	root.AddCommand(removeCmd)
+
+	removeVertex := &cobra.Command{
+		Use:   "vertex <name>",
+		Short: "Remove a vertex and every edge connected to it",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			removeVertexFunc(args[0])
+		},
+	}
+	removeCmd.AddCommand(removeVertex)
+
+	removeEdge := &cobra.Command{
+		Use:   "edge <uuid>",
+		Short: "Remove an edge by uuid",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			removeEdgeFunc(args[0])
+		},
+	}
+	removeCmd.AddCommand(removeEdge)
+
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the properties of a vertex or edge in the existing graph",
+	}
+	root.AddCommand(updateCmd)
+
+	var unset []string
+
+	updateVertex := &cobra.Command{
+		Use:   "vertex <name> [key=value]...",
+		Short: "Set vertex properties",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			updatePropertiesFunc(args[0], "", args[1:], unset)
+		},
+	}
+	updateVertex.Flags().StringSliceVar(&unset, "unset", nil, "properties to remove")
+	updateCmd.AddCommand(updateVertex)
+
+	updateEdge := &cobra.Command{
+		Use:   "edge <uuid> [key=value]...",
+		Short: "Set edge properties",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			updatePropertiesFunc("", args[0], args[1:], unset)
+		},
+	}
+	updateEdge.Flags().StringSliceVar(&unset, "unset", nil, "properties to remove")
+	updateCmd.AddCommand(updateEdge)
+
+	var analyzeSelector string
+	var perSelector bool
+
+	analyze := &cobra.Command{
+		Use:   "analyze",
+		Short: "Report the components, articulation points and bridges of the graph",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			analyzeFunc(analyzeSelector, perSelector)
+		},
+	}
+	analyze.Flags().StringVar(&analyzeSelector, "selector", "", "only analyze edges with this selector")
+	analyze.Flags().BoolVar(&perSelector, "per-selector", false, "analyze each selector separately")
+	root.AddCommand(analyze)
+
+	var flowSelector string
+	var bandwidth int64
+
+	maxflow := &cobra.Command{
+		Use:   "maxflow <sources> <sinks>",
+		Short: "Compute the max flow and min cut between comma separated vertex sets",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			maxFlowFunc(strings.Split(args[0], ","), strings.Split(args[1], ","), flowSelector, bandwidth)
+		},
+	}
+	maxflow.Flags().StringVar(&flowSelector, "selector", "", "only use edges with this selector")
+	maxflow.Flags().Int64Var(&bandwidth, "bandwidth", 0, "check if this bandwidth can be delivered")
+	root.AddCommand(maxflow)
+
+	listGraphs := &cobra.Command{
+		Use:   "list",
+		Short: "List the named graphs and views",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			listGraphsFunc()
+		},
+	}
+	root.AddCommand(listGraphs)
+
+	deriveReq := &protocol.DeriveGraphRequest{}
+
+	derive := &cobra.Command{
+		Use:   "derive <name>",
+		Short: "Create a named view or copy of a graph",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			deriveReq.GraphName = args[0]
+			deriveFunc(deriveReq)
+		},
+	}
+	derive.Flags().StringVar(&deriveReq.Source, "from", "", "graph to derive from, the default graph if not set")
+	derive.Flags().StringVar(&deriveReq.VertexFilter, "vertex-filter", "", "only include vertices matching this filter")
+	derive.Flags().StringVar(&deriveReq.EdgeFilter, "edge-filter", "", "only include edges matching this filter")
+	derive.Flags().StringSliceVar(&deriveReq.Selectors, "selector", nil, "only include edges with these selectors")
+	derive.Flags().BoolVar(&deriveReq.Copy, "copy", false, "store a copy instead of a view that follows the source")
+	root.AddCommand(derive)
+
+	reservations := &cobra.Command{
+		Use:   "reservations",
+		Short: "List the reservations against a graph",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			reservationsFunc()
+		},
+	}
+	root.AddCommand(reservations)
+
+	release := &cobra.Command{
+		Use:   "release <name>",
+		Short: "Release a reservation, crediting its resources back",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			releaseFunc(args[0])
+		},
+	}
+	root.AddCommand(release)
+
+	commit := &cobra.Command{
+		Use:   "commit <name>",
+		Short: "Commit a held reservation",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			commitFunc(args[0])
+		},
+	}
+	root.AddCommand(commit)
+
 	root.Execute()
 }
 
 func createNetworkItemFunc() {
 	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
 		fmt.Printf("sent request\n")
-		resp, err := c.CreateGraph(context.TODO(), &protocol.CreateGraphRequest{})
+		resp, err := c.CreateGraph(context.TODO(), &protocol.CreateGraphRequest{GraphName: graphName})
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		fmt.Printf("%v\n", resp)
+		printDiff(resp.Diff)
 
 		return nil
 	})
@@ -114,7 +358,7 @@ func createNetworkItemFunc() {
 func delNetworkItemFunc() {
 	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
 		fmt.Printf("sent request\n")
-		resp, err := c.DeleteGraph(context.TODO(), &protocol.DeleteGraphRequest{})
+		resp, err := c.DeleteGraph(context.TODO(), &protocol.DeleteGraphRequest{GraphName: graphName})
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -127,7 +371,7 @@ func delNetworkItemFunc() {
 
 func showConfigFunc() {
 	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
-		resp, err := c.ShowGraph(context.TODO(), &protocol.ShowGraphRequest{})
+		resp, err := c.ShowGraph(context.TODO(), &protocol.ShowGraphRequest{GraphName: graphName})
 
 		if err != nil {
 			log.Fatal(err)
@@ -153,19 +397,92 @@ func showConfigFunc() {
 	})
 }
 
-func getNetworkItemFunc() {
+func getNetworkItemFunc(req *protocol.GetGraphRequest) {
 	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
-		resp, err := c.GetGraph(context.TODO(), &protocol.GetGraphRequest{})
+		req.GraphName = graphName
+		resp, err := c.GetGraph(context.TODO(), req)
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		// an explicit format is printed as is so it can be saved to a file
+		if req.Format != "" {
+			fmt.Print(resp.Graph)
+			return nil
+		}
+
+		fmt.Printf("Version: %s\n", resp.Version)
 		fmt.Printf("Graph: %s\n", resp.Graph)
 
 		return nil
 	})
 }
 
+func snapshotFunc(name string) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.SnapshotGraph(context.TODO(), &protocol.SnapshotGraphRequest{
+			GraphName: graphName,
+			Name:      name,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s\n", resp.Version)
+
+		return nil
+	})
+}
+
+func watchFunc(initial bool) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		stream, err := c.WatchGraph(context.TODO(), &protocol.WatchGraphRequest{
+			GraphName: graphName,
+			Initial:   initial,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("revision %d\n", resp.Revision)
+			for _, ev := range resp.Events {
+				printGraphEvent(ev)
+			}
+		}
+	})
+}
+
+func printGraphEvent(ev *protocol.GraphEvent) {
+	switch ev.Kind {
+	case pkg.EventEdge:
+		fmt.Printf("  %s edge %s (%s)", ev.Action, ev.Name, strings.Join(ev.Vertices, " -- "))
+	default:
+		fmt.Printf("  %s vertex %s", ev.Action, ev.Name)
+	}
+
+	keys := make([]string, 0, len(ev.Properties))
+	for k := range ev.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf(" %s=%s", k, ev.Properties[k])
+	}
+	for _, k := range ev.Unset {
+		fmt.Printf(" -%s", k)
+	}
+	fmt.Printf("\n")
+}
+
 func setHostFunc(host, port string) {
 	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
 		resp, err := c.SetCBSLocation(context.TODO(), &protocol.SetCBSRequest{
@@ -183,33 +500,369 @@ func setHostFunc(host, port string) {
 	})
 }
 
-func solveFunc(fi string) {
-
+func loadNetworkItemFunc(fi, format string) {
 	data, err := ioutil.ReadFile(fi)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	cons := make([]*protocol.Constraint, 0)
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(fi), ".")
+	}
+
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.LoadGraph(context.TODO(), &protocol.LoadGraphRequest{
+			GraphName: graphName,
+			Graph:     string(data),
+			Format:    format,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("loaded %s: %d vertices, %d edges\n", fi, resp.Vertices, resp.Edges)
+
+		return nil
+	})
+}
+
+func diffNetworkItemFunc(fi, format string) {
+	req := &protocol.DiffGraphRequest{GraphName: graphName}
+	if fi != "" {
+		data, err := ioutil.ReadFile(fi)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if format == "" {
+			format = strings.TrimPrefix(filepath.Ext(fi), ".")
+		}
+
+		req.Graph = string(data)
+		req.Format = format
+	}
 
-	err = json.Unmarshal(data, &cons)
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.DiffGraph(context.TODO(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printDiff(resp.Diff)
+
+		return nil
+	})
+}
+
+func printDiff(diff string) {
+	d := &graph.GraphDiff{}
+	err := json.Unmarshal([]byte(diff), d)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	//TODO: read constraints from file
+	if d.Empty() {
+		fmt.Printf("no changes\n")
+		return
+	}
+
+	fmt.Print(d.String())
+}
+
+func renderNetworkItemFunc(fi string, req *protocol.RenderGraphRequest) {
+	if req.Format == "" {
+		req.Format = strings.TrimPrefix(filepath.Ext(fi), ".")
+	}
+
 	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
-		// TODO: add constraints here
-		resp, err := c.RequestSolution(context.TODO(), &protocol.SolveRequest{
-			Constraints: cons,
-		})
+		req.GraphName = graphName
+		resp, err := c.RenderGraph(context.TODO(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
 
+		err = ioutil.WriteFile(fi, resp.Image, 0644)
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		fmt.Printf("wrote %s\n", fi)
+
+		return nil
+	})
+}
+
+func solveFunc(fi string, req *protocol.SolveRequest, explain bool) {
+
+	cons, err := constraint.ReadFile(fi)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if explain {
+		explainFunc(cons)
+		return
+	}
+
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		req.Constraints = cons
+		req.GraphName = graphName
+		resp, err := c.RequestSolution(context.TODO(), req)
+
+		if err != nil {
+			fatalConstraints(err)
+		}
+
 		fmt.Printf("%+v\n", resp.Response)
+		fmt.Printf("graph version: %s\n", resp.GraphVersion)
+		fmt.Printf("solver: %s\n", resp.Solver)
+		if req.Reserve != "" && req.HoldTtl > 0 {
+			fmt.Printf("held: %s for %ds\n", req.Reserve, req.HoldTtl)
+		} else if req.Reserve != "" {
+			fmt.Printf("reserved: %s\n", req.Reserve)
+		}
+
+		return nil
+	})
+}
+
+func removeVertexFunc(name string) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.RemoveVertex(context.TODO(), &protocol.RemoveVertexRequest{
+			GraphName: graphName,
+			Name:      name,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("removed %s and edges: %v\n", name, resp.Edges)
+
+		return nil
+	})
+}
+
+func removeEdgeFunc(uuid string) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.RemoveEdge(context.TODO(), &protocol.RemoveEdgeRequest{
+			GraphName: graphName,
+			Uuid:      uuid,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%+v\n", resp)
+
+		return nil
+	})
+}
+
+func updatePropertiesFunc(vertex, edge string, kvs, unset []string) {
+	set := make(map[string]string)
+	for _, kv := range kvs {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 {
+			log.Fatalf("property must be key=value: %s", kv)
+		}
+		set[pair[0]] = pair[1]
+	}
+
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.UpdateProperties(context.TODO(), &protocol.UpdatePropertiesRequest{
+			GraphName: graphName,
+			Vertex:    vertex,
+			Edge:      edge,
+			Set:       set,
+			Unset:     unset,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%+v\n", resp)
+
+		return nil
+	})
+}
+
+func analyzeFunc(selector string, perSelector bool) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.AnalyzeGraph(context.TODO(), &protocol.AnalyzeGraphRequest{
+			GraphName:   graphName,
+			Selector:    selector,
+			PerSelector: perSelector,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, a := range resp.Analyses {
+			if a.Selector != "" {
+				fmt.Printf("selector: %s\n", a.Selector)
+			}
+			fmt.Printf("components: %d\n", len(a.Components))
+			for i, comp := range a.Components {
+				fmt.Printf("  %d: %s\n", i, strings.Join(comp.Vertices, " "))
+			}
+			fmt.Printf("articulation points: %s\n", strings.Join(a.ArticulationPoints, " "))
+			fmt.Printf("bridges: %s\n", strings.Join(a.Bridges, " "))
+		}
+
+		return nil
+	})
+}
+
+func maxFlowFunc(sources, sinks []string, selector string, bandwidth int64) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.MaxFlow(context.TODO(), &protocol.MaxFlowRequest{
+			GraphName: graphName,
+			Sources:   sources,
+			Sinks:     sinks,
+			Selector:  selector,
+			Bandwidth: bandwidth,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("max flow: %g\n", resp.Value)
+		fmt.Printf("min cut: %s\n", strings.Join(resp.Cut, " "))
+		for _, ef := range resp.Flows {
+			fmt.Printf("  %s: %s -> %s %g\n", ef.Edge, ef.From, ef.To, ef.Value)
+		}
+
+		if bandwidth > 0 {
+			fmt.Printf("bandwidth %d achievable: %t\n", bandwidth, resp.Feasible)
+		}
+
+		return nil
+	})
+}
+
+func listGraphsFunc() {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.ListGraphs(context.TODO(), &protocol.ListGraphsRequest{})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, g := range resp.Graphs {
+			printGraphInfo(g)
+		}
+
+		return nil
+	})
+}
+
+func deriveFunc(req *protocol.DeriveGraphRequest) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.DeriveGraph(context.TODO(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printGraphInfo(resp.Graph)
+
+		return nil
+	})
+}
+
+func reservationsFunc() {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.ListReservations(context.TODO(), &protocol.ListReservationsRequest{
+			GraphName: graphName,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("ledger: %s\n", resp.GraphName)
+		for _, r := range resp.Reservations {
+			printReservation(r)
+		}
 
 		return nil
 	})
 }
+
+func releaseFunc(name string) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.ReleaseReservation(context.TODO(), &protocol.ReleaseReservationRequest{
+			GraphName: graphName,
+			Name:      name,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("released ")
+		printReservation(resp.Reservation)
+
+		return nil
+	})
+}
+
+func commitFunc(name string) {
+	pkg.WithNetwork(addr, func(c protocol.NetworkClient) error {
+		resp, err := c.CommitReservation(context.TODO(), &protocol.CommitReservationRequest{
+			GraphName: graphName,
+			Name:      name,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("committed ")
+		printReservation(resp.Reservation)
+
+		return nil
+	})
+}
+
+func printReservation(r *protocol.Reservation) {
+	if r.Expires != "" {
+		fmt.Printf("%s (%s, held until %s)\n", r.Name, r.Created, r.Expires)
+	} else {
+		fmt.Printf("%s (%s)\n", r.Name, r.Created)
+	}
+	for _, res := range r.Resources {
+		fmt.Printf("  %s %s %s %g\n", res.Kind, res.Name, res.Property, res.Amount)
+	}
+}
+
+func printGraphInfo(g *protocol.GraphInfo) {
+	fmt.Printf("%s (%s): %d vertices, %d edges\n", g.Name, g.Kind, g.Vertices, g.Edges)
+	if g.Source != "" {
+		fmt.Printf("  source: %s\n", g.Source)
+	}
+	if g.VertexFilter != "" {
+		fmt.Printf("  vertex filter: %s\n", g.VertexFilter)
+	}
+	if g.EdgeFilter != "" {
+		fmt.Printf("  edge filter: %s\n", g.EdgeFilter)
+	}
+}
+
+// fatalConstraints prints what is wrong with each constraint field before
+// exiting, or the error when it is not about constraints.
+func fatalConstraints(err error) {
+	errs := constraint.FromError(err)
+	if len(errs) == 0 {
+		log.Fatal(err)
+	}
+
+	for _, fe := range errs {
+		fmt.Printf("%s\n", fe)
+	}
+	log.Fatal(constraint.ErrInvalid)
+}
+
+// explainFunc prints the constraints a request file compiles to
+func explainFunc(cons []*protocol.Constraint) {
+	out, err := constraint.Explain(cons)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(out)
+}
@@ -0,0 +1,398 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+// Operator compares a property with the value of a constraint
+type Operator string
+
+const (
+	Less         Operator = "<"
+	LessEqual    Operator = "<="
+	Equal        Operator = "="
+	GreaterEqual Operator = ">="
+	Greater      Operator = ">"
+	NotEqual     Operator = "!="
+)
+
+// ParseOperator returns the operator of s, == is the same as =.
+func ParseOperator(s string) (Operator, error) {
+	switch op := Operator(strings.TrimSpace(s)); op {
+	case Less, LessEqual, Equal, GreaterEqual, Greater, NotEqual:
+		return op, nil
+	case "==":
+		return Equal, nil
+	case "":
+		return "", fmt.Errorf("not set")
+	}
+
+	return "", fmt.Errorf("unknown operator, use <, <=, =, !=, >= or >")
+}
+
+// Holds checks the result of comparing a property with the value, -1, 0
+// or 1 as the property is less, equal or greater.
+func (o Operator) Holds(cmp int) bool {
+	switch o {
+	case Less:
+		return cmp < 0
+	case LessEqual:
+		return cmp <= 0
+	case Equal:
+		return cmp == 0
+	case GreaterEqual:
+		return cmp >= 0
+	case Greater:
+		return cmp > 0
+	case NotEqual:
+		return cmp != 0
+	}
+	return false
+}
+
+// Object is what a constraint is about
+type Object string
+
+const (
+	Latency   Object = "latency"
+	Bandwidth Object = "bandwidth"
+	CPU       Object = "cpu"
+	Memory    Object = "memory"
+	Disk      Object = "disk"
+	Distance  Object = "distance"
+	Owner     Object = "owner"
+	Trust     Object = "trust"
+	// UntrustedHops counts the edges of a path, and the vertices it passes
+	// through, with trust 0 or none.  The vertices at its ends are not
+	// counted.
+	UntrustedHops Object = "untrusted-hops"
+)
+
+// objectAliases are the names an object is given by, the graph property
+// names included
+var objectAliases = map[string]Object{
+	"latency":   Latency,
+	"lat":       Latency,
+	"bandwidth": Bandwidth,
+	"bw":        Bandwidth,
+	"cpu":       CPU,
+	"memory":    Memory,
+	"mem":       Memory,
+	"disk":      Disk,
+	"storage":   Disk,
+	"distance":  Distance,
+	"owner":     Owner,
+	"domain":    Owner,
+	"trust":     Trust,
+
+	"untrusted-hops": UntrustedHops,
+	"untrusted":      UntrustedHops,
+}
+
+// objectKeys are the graph properties of the objects
+var objectKeys = map[Object]string{
+	Latency:   "lat",
+	Bandwidth: "bw",
+	CPU:       "cpu",
+	Memory:    "mem",
+	Disk:      "disk",
+	Distance:  "distance",
+	Owner:     "owner",
+	Trust:     "trust",
+}
+
+// ParseObject returns the object named by s
+func ParseObject(s string) (Object, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" {
+		return "", fmt.Errorf("not set")
+	}
+
+	o, ok := objectAliases[name]
+	if !ok {
+		return "", fmt.Errorf("unknown object, use latency, bandwidth, cpu, memory, disk, distance, owner, trust or untrusted-hops")
+	}
+	return o, nil
+}
+
+// Key returns the graph property the object is about, untrusted hops are
+// counted from trust.
+func (o Object) Key() string {
+	return objectKeys[o]
+}
+
+// OnVertices checks if the object is a vertex property, the others are
+// edge properties.
+func (o Object) OnVertices() bool {
+	return o == CPU || o == Memory || o == Disk
+}
+
+// OnPath checks if the object is a property of both the vertices and the
+// edges, that every one a slice goes through must meet.
+func (o Object) OnPath() bool {
+	return o == Owner || o == Trust
+}
+
+// Untrusted checks if a vertex or edge is untrusted, its trust is 0 or not
+// set.
+func Untrusted(props map[string]string) (bool, error) {
+	v, err := graph.GetAttr(props, objectKeys[Trust])
+	if errors.Is(err, graph.ErrAttrMissing) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return v.Num <= 0, nil
+}
+
+// Locale is where a constraint holds
+type Locale string
+
+const (
+	// Local holds for each vertex or edge
+	Local Locale = "local"
+	// Global holds for the path between every two vertices of a slice
+	Global Locale = "global"
+)
+
+// ParseLocale returns the locale of s, local when it is empty
+func ParseLocale(s string) (Locale, error) {
+	switch l := Locale(strings.ToLower(strings.TrimSpace(s))); l {
+	case "":
+		return Local, nil
+	case Local, Global:
+		return l, nil
+	}
+
+	return "", fmt.Errorf("unknown locale, use local or global")
+}
+
+// Constraint is a parsed and validated protocol.Constraint.  The value is in
+// the unit of the graph property of the object, so "1Gbps" is bandwidth
+// 1000 (Mbps).
+type Constraint struct {
+	// Index is the position of the constraint in its request
+	Index    int
+	Operator Operator
+	Object   Object
+	Value    graph.Value
+	Locale   Locale
+	Vertices []string
+	Edges    []string
+	Selector string
+}
+
+func (c *Constraint) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Object, c.Operator, c.Value)
+	if c.Value.Unit != graph.UnitNone {
+		s += string(c.Value.Unit)
+	}
+	if c.Locale == Global {
+		s += " global"
+	}
+	return s
+}
+
+// Proto returns the constraint as it is sent to a solver, with the names
+// of its object, operator and locale and its value a bare number in the
+// unit of the graph property, as the graph has it.  "1Gbps" is "1000".
+func (c *Constraint) Proto() *protocol.Constraint {
+	return &protocol.Constraint{
+		Operator: string(c.Operator),
+		Lvalue:   c.Value.String(),
+		Object:   string(c.Object),
+		Locale:   string(c.Locale),
+		Vertices: c.Vertices,
+		Edges:    c.Edges,
+		Selector: c.Selector,
+	}
+}
+
+// Holds checks a property value against the constraint
+func (c *Constraint) Holds(v graph.Value) (bool, error) {
+	cmp, err := v.Compare(c.Value)
+	if err != nil {
+		return false, err
+	}
+	return c.Operator.Holds(cmp), nil
+}
+
+// HoldsFor checks the property of a vertex or edge against the constraint,
+// a vertex or edge without the property does not meet it unless it is to
+// not equal the value.
+func (c *Constraint) HoldsFor(props map[string]string) (bool, error) {
+	raw, ok := props[c.Object.Key()]
+	if !ok {
+		return c.Operator == NotEqual, nil
+	}
+
+	v, err := graph.ParseAttr(c.Object.Key(), raw)
+	if err != nil {
+		return false, err
+	}
+	return c.Holds(v)
+}
+
+// Parse validates a constraint on its own, without a graph.  The errors
+// are an Errors with one FieldError for each field that is wrong.
+func Parse(index int, cc *protocol.Constraint) (*Constraint, error) {
+	if cc == nil {
+		return nil, Errors{{Index: index, Reason: "constraint is empty"}}
+	}
+
+	var errs Errors
+	fail := func(field, value string, err error) {
+		errs = append(errs, &FieldError{Index: index, Field: field, Value: value, Reason: err.Error()})
+	}
+
+	c := &Constraint{
+		Index:    index,
+		Vertices: cc.Vertices,
+		Edges:    cc.Edges,
+		Selector: cc.Selector,
+	}
+
+	var err error
+	c.Operator, err = ParseOperator(cc.Operator)
+	if err != nil {
+		fail("operator", cc.Operator, err)
+	}
+
+	c.Locale, err = ParseLocale(cc.Locale)
+	if err != nil {
+		fail("locale", cc.Locale, err)
+	}
+
+	c.Object, err = ParseObject(cc.Object)
+	if err != nil {
+		fail("object", cc.Object, err)
+		return nil, errs
+	}
+
+	if strings.TrimSpace(cc.Lvalue) == "" {
+		fail("lvalue", cc.Lvalue, fmt.Errorf("not set"))
+	} else {
+		c.Value, err = graph.ParseAttr(c.Object.Key(), cc.Lvalue)
+		if err != nil {
+			fail("lvalue", cc.Lvalue, fmt.Errorf("not a %s: %w", c.Object, err))
+		} else if c.Object == UntrustedHops && (!c.Value.Numeric() || c.Value.Num < 0 || c.Value.Num != math.Trunc(c.Value.Num)) {
+			fail("lvalue", cc.Lvalue, fmt.Errorf("%s is a count of hops", c.Object))
+		}
+	}
+
+	// owners are named, the other objects are ordered
+	switch {
+	case c.Object == Owner && c.Operator != "" && c.Operator != Equal && c.Operator != NotEqual:
+		fail("operator", cc.Operator, fmt.Errorf("owner is either = or != a domain"))
+	case c.Object != Owner && c.Operator == NotEqual:
+		fail("operator", cc.Operator, fmt.Errorf("%s is compared with <, <=, =, >= or >", c.Object))
+	}
+
+	switch {
+	case c.Object == UntrustedHops:
+		// a count along the path between the vertices of the slice
+		if c.Locale == Local && strings.TrimSpace(cc.Locale) != "" {
+			fail("locale", cc.Locale, fmt.Errorf("%s is counted along a path, it is global", c.Object))
+		}
+		c.Locale = Global
+		if len(c.Edges) > 0 {
+			fail("edges", strings.Join(c.Edges, ","), fmt.Errorf("%s is counted along a path", c.Object))
+		}
+
+	case c.Object.OnPath():
+		if c.Locale == Global {
+			fail("locale", cc.Locale, fmt.Errorf("%s holds for each vertex and edge, it can not be global", c.Object))
+		}
+	}
+
+	if c.Object.OnVertices() {
+		if c.Locale == Global {
+			fail("locale", cc.Locale, fmt.Errorf("%s holds for each vertex, it can not be global", c.Object))
+		}
+		if len(c.Edges) > 0 {
+			fail("edges", strings.Join(c.Edges, ","), fmt.Errorf("%s is a vertex property", c.Object))
+		}
+	}
+
+	for _, name := range c.Vertices {
+		if strings.TrimSpace(name) == "" {
+			fail("vertices", name, fmt.Errorf("empty vertex name"))
+		}
+	}
+	for _, id := range c.Edges {
+		if strings.TrimSpace(id) == "" {
+			fail("edges", id, fmt.Errorf("empty edge id"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return c, nil
+}
+
+// ParseAll validates the constraints of a request.  Every wrong field of
+// every constraint is reported, in an Errors.
+func ParseAll(cons []*protocol.Constraint) ([]*Constraint, error) {
+	if len(cons) == 0 {
+		return nil, Errors{{Index: -1, Field: "constraints", Reason: "no constraints given"}}
+	}
+
+	var errs Errors
+	out := make([]*Constraint, 0, len(cons))
+	for i, cc := range cons {
+		c, err := Parse(i, cc)
+		if err != nil {
+			errs = append(errs, err.(Errors)...)
+			continue
+		}
+		out = append(out, c)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return out, nil
+}
+
+// Resolve checks that the vertices, edges and selectors the constraints
+// refer to are in the graph.  Edges are found by id, the uuid property.
+func Resolve(cons []*Constraint, g *graph.Graph) error {
+	selectors := make(map[string]bool)
+	for _, e := range g.Edges {
+		if sel, ok := e.Properties["selector"]; ok {
+			selectors[sel] = true
+		}
+	}
+
+	var errs Errors
+	for _, c := range cons {
+		for _, name := range c.Vertices {
+			if _, ok := g.GetVertex(name); !ok {
+				errs = append(errs, &FieldError{Index: c.Index, Field: "vertices", Value: name, Reason: "no such vertex in the graph"})
+			}
+		}
+
+		for _, id := range c.Edges {
+			if len(g.GetEdgesByID(id)) == 0 {
+				errs = append(errs, &FieldError{Index: c.Index, Field: "edges", Value: id, Reason: "no such edge in the graph"})
+			}
+		}
+
+		if c.Selector != "" && !selectors[c.Selector] {
+			errs = append(errs, &FieldError{Index: c.Index, Field: "selector", Value: c.Selector, Reason: "no edges with this selector"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package constraint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		cc   *protocol.Constraint
+		want string
+		num  float64
+	}{
+		{&protocol.Constraint{Object: "latency", Operator: "<", Lvalue: "5ms"}, "latency < 5ms", 5},
+		{&protocol.Constraint{Object: "lat", Operator: "<=", Lvalue: "0.5s", Locale: "global"}, "latency <= 500ms global", 500},
+		{&protocol.Constraint{Object: "Bandwidth", Operator: ">=", Lvalue: "1Gbps"}, "bandwidth >= 1000Mbps", 1000},
+		{&protocol.Constraint{Object: "distance", Operator: "<", Lvalue: "100 miles"}, "distance < 160.9344km", 160.9344},
+		{&protocol.Constraint{Object: "cpu", Operator: "==", Lvalue: "8", Vertices: []string{"a"}}, "cpu = 8cores", 8},
+		{&protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "2"}, "trust >= 2", 2},
+		{&protocol.Constraint{Object: "untrusted", Operator: "<=", Lvalue: "1"}, "untrusted-hops <= 1 global", 1},
+	} {
+		c, err := Parse(0, tc.cc)
+		if err != nil {
+			t.Fatalf("%v: %v", tc.cc, err)
+		}
+		assert.Equal(t, tc.want, c.String())
+		assert.Equal(t, tc.num, c.Value.Num)
+
+		// what a solver is sent parses to the same constraint
+		cc := c.Proto()
+		assert.Equal(t, c.Value.String(), cc.Lvalue)
+		again, err := Parse(0, cc)
+		if err != nil {
+			t.Fatalf("%v: %v", cc, err)
+		}
+		assert.Equal(t, c, again)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := ParseAll([]*protocol.Constraint{
+		{Object: "latency", Operator: "<", Lvalue: "5ms"},
+		{Object: "latency", Operator: "~", Lvalue: "10Mbps", Locale: "near"},
+		{Object: "colour", Operator: "=", Lvalue: "red"},
+		{Object: "cpu", Operator: ">", Lvalue: "1.5", Locale: "global", Edges: []string{"ab"}},
+	})
+	assert.True(t, errors.Is(err, ErrInvalid))
+
+	errs := FromError(err)
+	fields := make([][2]interface{}, 0)
+	for _, fe := range errs {
+		fields = append(fields, [2]interface{}{fe.Index, fe.Field})
+	}
+	assert.Equal(t, [][2]interface{}{
+		{1, "operator"}, {1, "locale"}, {1, "lvalue"},
+		{2, "object"},
+		{3, "lvalue"}, {3, "locale"}, {3, "edges"},
+	}, fields)
+	assert.Equal(t, `constraint 1 operator "~": unknown operator, use <, <=, =, !=, >= or >`, errs[0].Error())
+
+	_, err = ParseAll([]*protocol.Constraint{
+		{Object: "domain", Operator: "!=", Lvalue: "acme"},
+		{Object: "owner", Operator: ">", Lvalue: "acme"},
+		{Object: "trust", Operator: "!=", Lvalue: "1", Locale: "global"},
+		{Object: "untrusted-hops", Operator: "<", Lvalue: "1.5", Locale: "local", Edges: []string{"ab"}},
+	})
+	errs = FromError(err)
+	fields = fields[:0]
+	for _, fe := range errs {
+		fields = append(fields, [2]interface{}{fe.Index, fe.Field})
+	}
+	assert.Equal(t, [][2]interface{}{
+		{1, "operator"},
+		{2, "operator"}, {2, "locale"},
+		{3, "lvalue"}, {3, "locale"}, {3, "edges"},
+	}, fields)
+
+	_, err = ParseAll(nil)
+	assert.Equal(t, "constraints: no constraints given", FromError(err)[0].Error())
+}
+
+func TestHoldsFor(t *testing.T) {
+	avoid, err := Parse(0, &protocol.Constraint{Object: "owner", Operator: "!=", Lvalue: "acme"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	minTrust, err := Parse(1, &protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "1"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	for _, tc := range []struct {
+		props           map[string]string
+		avoid, minTrust bool
+	}{
+		{map[string]string{"owner": "acme", "trust": "3"}, false, true},
+		{map[string]string{"owner": "other", "trust": "0"}, true, false},
+		// no owner is not acme, no trust is untrusted
+		{map[string]string{}, true, false},
+	} {
+		ok, err := avoid.HoldsFor(tc.props)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.avoid, ok, "%v", tc.props)
+
+		ok, err = minTrust.HoldsFor(tc.props)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.minTrust, ok, "%v", tc.props)
+
+		untrusted, err := Untrusted(tc.props)
+		assert.Nil(t, err)
+		assert.Equal(t, !tc.minTrust, untrusted, "%v", tc.props)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	G := &graph.Graph{}
+	_, err := G.AddEdge(&graph.Vertex{Name: "a"}, &graph.Vertex{Name: "b"},
+		map[string]string{"uuid": "ab", "selector": "netA"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	cons, err := ParseAll([]*protocol.Constraint{
+		{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "c"}},
+		{Object: "bw", Operator: ">", Lvalue: "1", Edges: []string{"ab", "bc"}, Selector: "netA"},
+		{Object: "bw", Operator: ">", Lvalue: "1", Selector: "netB"},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	errs := FromError(Resolve(cons, G))
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, &FieldError{Index: 0, Field: "vertices", Value: "c", Reason: "no such vertex in the graph"}, errs[0])
+	assert.Equal(t, "edges", errs[1].Field)
+	assert.Equal(t, "selector", errs[2].Field)
+
+	assert.Nil(t, Resolve(cons[:0], G))
+}
+
+func TestGRPCStatus(t *testing.T) {
+	sent := Errors{
+		{Index: 0, Field: "lvalue", Value: "fast", Reason: "not a number"},
+		{Index: 2, Field: "object", Value: "colour", Reason: "unknown object"},
+	}
+
+	// what a client gets back from the status of a handler returning Errors
+	err := sent.GRPCStatus().Err()
+	assert.Equal(t, sent, FromError(err))
+	assert.Nil(t, FromError(errors.New("not about constraints")))
+}
@@ -0,0 +1,282 @@
+package constraint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+// The constraint language has a constraint on each line:
+//
+//	<object> <operator> <value> [global|local] [on <selector>] [at <vertices>] [edges <edges>]
+//
+// for example
+//
+//	# two sites no further than 5ms apart
+//	cpu >= 8 at site-a, site-b
+//	latency < 5ms global
+//	bandwidth >= 1Gbps on net-a
+//	owner != acme
+//	untrusted-hops <= 1
+//
+// A value may have its unit apart, "100 miles".  Lists are separated by
+// commas or spaces and run to the next keyword.  # starts a comment.
+
+// DSLExt is the file extension of constraint language files
+const DSLExt = ".sabres"
+
+// SyntaxError is an error on a line of constraint language
+type SyntaxError struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Line == 0 {
+		return e.Reason
+	}
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Reason, e.Text)
+}
+
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// clause keywords that follow the value
+var clauses = map[string]bool{
+	"global": true,
+	"local":  true,
+	"on":     true,
+	"at":     true,
+	"edges":  true,
+}
+
+// Compile turns constraint language into constraints.  Each constraint is
+// validated as Parse does, without a graph.
+func Compile(src string) ([]*protocol.Constraint, error) {
+	cons := make([]*protocol.Constraint, 0)
+	for i, line := range strings.Split(src, "\n") {
+		text := line
+		if j := strings.Index(text, "#"); j >= 0 {
+			text = text[:j]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		cc, err := compileLine(text)
+		if err == nil {
+			_, err = Parse(len(cons), cc)
+			if errs, ok := err.(Errors); ok {
+				msgs := make([]string, 0, len(errs))
+				for _, fe := range errs {
+					msgs = append(msgs, fmt.Sprintf("%s %q: %s", fe.Field, fe.Value, fe.Reason))
+				}
+				err = fmt.Errorf("%s", strings.Join(msgs, "; "))
+			}
+		}
+		if err != nil {
+			return nil, &SyntaxError{Line: i + 1, Text: strings.TrimSpace(line), Reason: err.Error()}
+		}
+
+		cons = append(cons, cc)
+	}
+
+	if len(cons) == 0 {
+		return nil, &SyntaxError{Reason: "no constraints"}
+	}
+	return cons, nil
+}
+
+// compileLine turns one line into a constraint
+func compileLine(text string) (*protocol.Constraint, error) {
+	toks := tokenize(text)
+	if len(toks) < 3 {
+		return nil, fmt.Errorf("want <object> <operator> <value>")
+	}
+
+	cc := &protocol.Constraint{
+		Object:   toks[0],
+		Operator: toks[1],
+		Lvalue:   toks[2],
+	}
+	toks = toks[3:]
+
+	// a unit written apart from its number
+	if len(toks) > 0 && !clauses[toks[0]] && isNumber(cc.Lvalue) {
+		cc.Lvalue += toks[0]
+		toks = toks[1:]
+	}
+
+	for len(toks) > 0 {
+		kw := toks[0]
+		toks = toks[1:]
+
+		var args []string
+		for len(toks) > 0 && !clauses[toks[0]] {
+			args = append(args, toks[0])
+			toks = toks[1:]
+		}
+
+		switch kw {
+		case "global", "local":
+			if cc.Locale != "" {
+				return nil, fmt.Errorf("locale given twice")
+			}
+			if len(args) > 0 {
+				return nil, fmt.Errorf("unexpected %s after %s", args[0], kw)
+			}
+			cc.Locale = kw
+
+		case "on":
+			if len(args) != 1 || cc.Selector != "" {
+				return nil, fmt.Errorf("on takes one selector")
+			}
+			cc.Selector = args[0]
+
+		case "at":
+			if len(args) == 0 {
+				return nil, fmt.Errorf("at needs vertices")
+			}
+			cc.Vertices = append(cc.Vertices, args...)
+
+		case "edges":
+			if len(args) == 0 {
+				return nil, fmt.Errorf("edges needs edge ids")
+			}
+			cc.Edges = append(cc.Edges, args...)
+
+		default:
+			return nil, fmt.Errorf("unexpected %s, want global, local, on, at or edges", kw)
+		}
+	}
+
+	return cc, nil
+}
+
+// tokenize splits a line at spaces and commas, an operator written against
+// its object or value, "latency<5ms" or "owner!=acme", is split off.
+func tokenize(text string) []string {
+	toks := make([]string, 0)
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			toks = append(toks, cur.String())
+			cur.Reset()
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r) || r == ',':
+			flush()
+
+		case r == '<' || r == '>' || r == '=' || r == '!':
+			flush()
+			cur.WriteRune(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				cur.WriteRune('=')
+				i++
+			}
+			flush()
+
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return toks
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) && r != '.' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// Format writes a constraint in the constraint language, with its value in
+// the unit it was normalised to.
+func Format(c *Constraint) string {
+	var b strings.Builder
+	b.WriteString(c.String())
+
+	if c.Selector != "" {
+		fmt.Fprintf(&b, " on %s", c.Selector)
+	}
+	if len(c.Vertices) > 0 {
+		fmt.Fprintf(&b, " at %s", strings.Join(c.Vertices, ", "))
+	}
+	if len(c.Edges) > 0 {
+		fmt.Fprintf(&b, " edges %s", strings.Join(c.Edges, ", "))
+	}
+
+	return b.String()
+}
+
+// ReadFile reads the constraints of a request file, constraint language
+// when it ends in DSLExt and a json list of constraints otherwise.
+func ReadFile(path string) ([]*protocol.Constraint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if filepath.Ext(path) == DSLExt {
+		cons, err := Compile(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return cons, nil
+	}
+
+	cons := make([]*protocol.Constraint, 0)
+	err = json.Unmarshal(data, &cons)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cons, nil
+}
+
+// Explain writes each constraint as it was compiled and how it reads once
+// validated.
+func Explain(cons []*protocol.Constraint) (string, error) {
+	var b strings.Builder
+
+	// operators are written as they are, not escaped for html
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	for i, cc := range cons {
+		fmt.Fprintf(&b, "%d: ", i)
+		err := enc.Encode(cc)
+		if err != nil {
+			return "", err
+		}
+
+		c, err := Parse(i, cc)
+		if err != nil {
+			fmt.Fprintf(&b, "   invalid: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(&b, "   %s\n", Format(c))
+	}
+
+	return b.String(), nil
+}
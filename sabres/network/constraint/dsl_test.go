@@ -0,0 +1,104 @@
+package constraint
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+func TestCompile(t *testing.T) {
+	cons, err := Compile(`
+# two sites close together
+cpu >= 8 at site-a, site-b
+latency<5ms global
+bandwidth >= 1Gbps on net-a   # the fast network
+distance <= 100 miles edges ab bc
+owner!=acme
+untrusted-hops <= 1
+`)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, []*protocol.Constraint{
+		{Object: "cpu", Operator: ">=", Lvalue: "8", Vertices: []string{"site-a", "site-b"}},
+		{Object: "latency", Operator: "<", Lvalue: "5ms", Locale: "global"},
+		{Object: "bandwidth", Operator: ">=", Lvalue: "1Gbps", Selector: "net-a"},
+		{Object: "distance", Operator: "<=", Lvalue: "100miles", Edges: []string{"ab", "bc"}},
+		{Object: "owner", Operator: "!=", Lvalue: "acme"},
+		{Object: "untrusted-hops", Operator: "<=", Lvalue: "1"},
+	}, cons)
+
+	// the formatted constraints compile to the same constraints again
+	for i, cc := range cons {
+		c, err := Parse(i, cc)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		again, err := Compile(Format(c))
+		if err != nil {
+			t.Fatalf("%s: %v", Format(c), err)
+		}
+		c2, err := Parse(i, again[0])
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		assert.Equal(t, c, c2)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src, want string
+	}{
+		{"latency < 5ms\nlatency", `line 2: want <object> <operator> <value>: "latency"`},
+		{"latency < 5ms nearby", `line 1: unexpected nearby, want global, local, on, at or edges: "latency < 5ms nearby"`},
+		{"bandwidth > 1Gbps on a b", `line 1: on takes one selector: "bandwidth > 1Gbps on a b"`},
+		{"latency ~ fast", `line 1: operator "~": unknown operator, use <, <=, =, !=, >= or >; lvalue "fast": not a latency: invalid attribute value: lat: not a number: fast: "latency ~ fast"`},
+		{"cpu > 4 global", `line 1: locale "global": cpu holds for each vertex, it can not be global: "cpu > 4 global"`},
+		{"# nothing here\n", "no constraints"},
+	} {
+		_, err := Compile(tc.src)
+		assert.True(t, errors.Is(err, ErrInvalid), "%s", tc.src)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, tc.want, err.Error())
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	dsl := filepath.Join(dir, "slice.sabres")
+	err := ioutil.WriteFile(dsl, []byte("cpu >= 8 at a\n"), 0644)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	js := filepath.Join(dir, "slice.json")
+	err = ioutil.WriteFile(js, []byte(`[{"operator": ">=", "lvalue": "8", "object": "cpu", "vertices": ["a"]}]`), 0644)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	a, err := ReadFile(dsl)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	b, err := ReadFile(js)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, a, b)
+
+	out, err := Explain(a)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "0: {\"operator\":\">=\",\"lvalue\":\"8\",\"object\":\"cpu\",\"vertices\":[\"a\"]}\n   cpu >= 8cores at a\n", out)
+}
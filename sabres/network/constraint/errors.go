@@ -0,0 +1,110 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+var ErrInvalid = errors.New("invalid constraint")
+
+// FieldError is what is wrong with one field of a constraint.  Index is the
+// position of the constraint in its request, -1 when the error is about the
+// request.  Field is the protocol.Constraint field name.
+type FieldError struct {
+	Index  int
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	where := "constraints"
+	if e.Index >= 0 {
+		where = fmt.Sprintf("constraint %d", e.Index)
+	}
+	if e.Field != "" && e.Field != "constraints" {
+		where += " " + e.Field
+	}
+	if e.Value != "" {
+		where += fmt.Sprintf(" %q", e.Value)
+	}
+	return fmt.Sprintf("%s: %s", where, e.Reason)
+}
+
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// Errors is every field error found in the constraints of a request.  It is
+// sent over grpc as an InvalidArgument status with the field errors as a
+// protocol.ConstraintErrors detail, FromError gets them back.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalid, strings.Join(msgs, "; "))
+}
+
+func (e Errors) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// GRPCStatus makes a grpc handler returning Errors answer with an
+// InvalidArgument status carrying the field errors.
+func (e Errors) GRPCStatus() *status.Status {
+	details := &protocol.ConstraintErrors{}
+	for _, fe := range e {
+		details.Errors = append(details.Errors, &protocol.ConstraintError{
+			Index:  int32(fe.Index),
+			Field:  fe.Field,
+			Value:  fe.Value,
+			Reason: fe.Reason,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, e.Error())
+	withDetails, err := st.WithDetails(details)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// FromError returns the field errors of err, either Errors or a grpc status
+// from it, nil when err is not about constraints.
+func FromError(err error) Errors {
+	var errs Errors
+	if errors.As(err, &errs) {
+		return errs
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	for _, d := range st.Details() {
+		ce, ok := d.(*protocol.ConstraintErrors)
+		if !ok {
+			continue
+		}
+		for _, fe := range ce.Errors {
+			errs = append(errs, &FieldError{
+				Index:  int(fe.Index),
+				Field:  fe.Field,
+				Value:  fe.Value,
+				Reason: fe.Reason,
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,195 @@
+package graph
+
+// Analysis is the structural report of a graph, or of the edges of a single
+// selector (network) within the graph.  Articulation points and bridges are
+// the vertices and edges whose loss disconnects part of their component.
+type Analysis struct {
+	Selector           string     `yaml:"selector" json:"selector"`
+	Components         [][]string `yaml:"components" json:"components"`
+	ArticulationPoints []string   `yaml:"articulationPoints" json:"articulationPoints"`
+	Bridges            []*Edge    `yaml:"bridges" json:"bridges"`
+}
+
+// Analyze finds the connected components, articulation points and bridges
+// of the whole graph.  Parallel edges between the same vertices back each
+// other up, so they are never bridges.
+func (g *Graph) Analyze() *Analysis {
+	return g.analyze("", nil)
+}
+
+// AnalyzeSelector analyzes only the edges with the given selector and the
+// vertices they connect.
+func (g *Graph) AnalyzeSelector(sel string) *Analysis {
+	return g.analyze(sel, func(e *Edge) bool {
+		return edgeSelector(e) == sel
+	})
+}
+
+// AnalyzeBySelector analyzes each selector in the graph separately.
+func (g *Graph) AnalyzeBySelector() []*Analysis {
+	analyses := make([]*Analysis, 0)
+	for _, sel := range g.Selectors() {
+		analyses = append(analyses, g.AnalyzeSelector(sel))
+	}
+	return analyses
+}
+
+// ConnectedComponents returns the vertex names of each connected component.
+func (g *Graph) ConnectedComponents() [][]string {
+	return g.Analyze().Components
+}
+
+// ArticulationPoints returns the vertices whose removal disconnects the graph.
+func (g *Graph) ArticulationPoints() []string {
+	return g.Analyze().ArticulationPoints
+}
+
+// Bridges returns the edges whose removal disconnects the graph.
+func (g *Graph) Bridges() []*Edge {
+	return g.Analyze().Bridges
+}
+
+// Selectors returns the distinct edge selectors in the order first seen.
+func (g *Graph) Selectors() []string {
+	seen := make(map[string]bool)
+	sels := make([]string, 0)
+	for _, e := range g.Edges {
+		sel := edgeSelector(e)
+		if sel == "" || seen[sel] {
+			continue
+		}
+		seen[sel] = true
+		sels = append(sels, sel)
+	}
+	return sels
+}
+
+func edgeSelector(e *Edge) string {
+	if e.Properties == nil {
+		return ""
+	}
+	return e.Properties["selector"]
+}
+
+// lowlink holds the state of tarjan's depth first search.
+type lowlink struct {
+	g       *Graph
+	keep    func(e *Edge) bool
+	disc    map[string]int
+	low     map[string]int
+	comp    map[string]int
+	time    int
+	cut     map[string]bool
+	bridges map[*Edge]bool
+}
+
+// analyze runs the search over the edges accepted by keep, nil keeps every
+// edge and every vertex.  With a filter, vertices without a kept edge are
+// left out of the report.
+func (g *Graph) analyze(sel string, keep func(e *Edge) bool) *Analysis {
+	g.ensureIndex()
+
+	l := &lowlink{
+		g:       g,
+		keep:    keep,
+		disc:    make(map[string]int),
+		low:     make(map[string]int),
+		comp:    make(map[string]int),
+		cut:     make(map[string]bool),
+		bridges: make(map[*Edge]bool),
+	}
+
+	members := func(name string) bool {
+		if keep == nil {
+			return true
+		}
+		for _, e := range g.adjIndex[name] {
+			if keep(e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	count := 0
+	for _, v := range g.Vertices {
+		if _, ok := l.disc[v.Name]; ok || !members(v.Name) {
+			continue
+		}
+		l.visit(v.Name, nil, count)
+		count++
+	}
+
+	a := &Analysis{
+		Selector:           sel,
+		Components:         make([][]string, count),
+		ArticulationPoints: make([]string, 0),
+		Bridges:            make([]*Edge, 0),
+	}
+
+	// report in graph order rather than search order
+	for _, v := range g.Vertices {
+		c, ok := l.comp[v.Name]
+		if !ok {
+			continue
+		}
+		a.Components[c] = append(a.Components[c], v.Name)
+		if l.cut[v.Name] {
+			a.ArticulationPoints = append(a.ArticulationPoints, v.Name)
+		}
+	}
+	for _, e := range g.Edges {
+		if l.bridges[e] {
+			a.Bridges = append(a.Bridges, e)
+		}
+	}
+
+	return a
+}
+
+// visit explores v, which was reached over the edge via.  Only via itself is
+// skipped on the way back, so a parallel edge counts as a back edge.
+func (l *lowlink) visit(v string, via *Edge, comp int) {
+	l.time++
+	l.disc[v] = l.time
+	l.low[v] = l.time
+	l.comp[v] = comp
+
+	children := 0
+	for _, e := range l.g.adjIndex[v] {
+		if e == via || (l.keep != nil && !l.keep(e)) {
+			continue
+		}
+
+		u := e.Other(v)
+		if u == v {
+			continue
+		}
+		if _, ok := l.g.vertexIndex[u]; !ok {
+			continue
+		}
+
+		if d, ok := l.disc[u]; ok {
+			if d < l.low[v] {
+				l.low[v] = d
+			}
+			continue
+		}
+
+		children++
+		l.visit(u, e, comp)
+		if l.low[u] < l.low[v] {
+			l.low[v] = l.low[u]
+		}
+		if via != nil && l.low[u] >= l.disc[v] {
+			l.cut[v] = true
+		}
+		if l.low[u] > l.disc[v] {
+			l.bridges[e] = true
+		}
+	}
+
+	if via == nil && children > 1 {
+		l.cut[v] = true
+	}
+}
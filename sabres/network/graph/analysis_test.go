@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bridgeIDs(edges []*Edge) []string {
+	return edgeIDs(&Path{Edges: edges})
+}
+
+func TestAnalyze(t *testing.T) {
+	G := testGraph(t, "test-analysis")
+
+	a := G.Analyze()
+	assert.Equal(t, [][]string{{"a", "b", "c", "d", "e"}, {"f"}}, a.Components)
+	assert.Equal(t, []string{"c", "d"}, a.ArticulationPoints)
+	assert.Equal(t, []string{"cd"}, bridgeIDs(a.Bridges), "parallel d=e edges are not bridges")
+
+	// losing one of the parallel edges makes the other a bridge
+	err := G.RemoveEdge("de2")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"cd", "de1"}, bridgeIDs(G.Bridges()))
+
+	// a fully redundant graph has no single points of failure
+	M := testGraph(t, "test-multi")
+	assert.Equal(t, 1, len(M.ConnectedComponents()))
+	assert.Empty(t, M.ArticulationPoints())
+	assert.Empty(t, M.Bridges())
+}
+
+func TestAnalyzeSelector(t *testing.T) {
+	G := testGraph(t, "test-analysis")
+
+	assert.Equal(t, []string{"x", "y"}, G.Selectors())
+
+	x := G.AnalyzeSelector("x")
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}}, x.Components)
+	assert.Equal(t, []string{"c"}, x.ArticulationPoints)
+	assert.Equal(t, []string{"cd"}, bridgeIDs(x.Bridges))
+
+	all := G.AnalyzeBySelector()
+	assert.Equal(t, 2, len(all))
+	assert.Equal(t, "y", all[1].Selector)
+	assert.Equal(t, [][]string{{"d", "e"}}, all[1].Components)
+	assert.Empty(t, all[1].ArticulationPoints)
+	assert.Empty(t, all[1].Bridges)
+
+	// each network of the multigraph on its own is a path
+	M := testGraph(t, "test-multi")
+	y := M.AnalyzeSelector("y")
+	assert.Equal(t, []string{"b"}, y.ArticulationPoints)
+	assert.Equal(t, []string{"ab2", "bd2"}, bridgeIDs(y.Bridges))
+}
@@ -0,0 +1,413 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Properties stay map[string]string so the json form of the graph does not
+// change, the attribute schema below gives them types and units.  Numbers
+// are stored in the canonical unit of their key, so "10Gbps" is stored as
+// bw "10000".
+
+var (
+	ErrAttrMissing  = errors.New("attribute not set")
+	ErrAttrInvalid  = errors.New("invalid attribute value")
+	ErrAttrMismatch = errors.New("attribute kinds do not match")
+)
+
+// Kind is the type of an attribute value
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	KindBool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindBool:
+		return "bool"
+	}
+	return "string"
+}
+
+// Unit is the canonical unit a numeric attribute is stored in
+type Unit string
+
+const (
+	UnitNone  Unit = ""
+	UnitMbps  Unit = "Mbps"
+	UnitMs    Unit = "ms"
+	UnitCores Unit = "cores"
+	UnitGiB   Unit = "GiB"
+	UnitKm    Unit = "km"
+)
+
+// unitScales are the suffixes accepted for each unit and their size in it.
+var unitScales = map[Unit]map[string]float64{
+	UnitMbps: {
+		"bps": 1e-6, "kbps": 1e-3, "mbps": 1, "gbps": 1e3, "tbps": 1e6,
+	},
+	UnitMs: {
+		"ns": 1e-6, "us": 1e-3, "µs": 1e-3, "ms": 1, "s": 1e3,
+	},
+	UnitCores: {
+		"core": 1, "cores": 1,
+	},
+	UnitGiB: {
+		"b": 1.0 / (1 << 30), "kib": 1.0 / (1 << 20), "mib": 1.0 / 1024, "gib": 1, "tib": 1024,
+		"kb": 1.0 / (1 << 20), "mb": 1.0 / 1024, "gb": 1, "tb": 1024,
+	},
+	UnitKm: {
+		"m": 1e-3, "km": 1, "mi": 1.609344, "mile": 1.609344, "miles": 1.609344,
+	},
+}
+
+// AttrSpec describes a known attribute key
+type AttrSpec struct {
+	Key  string `yaml:"key" json:"key"`
+	Kind Kind   `yaml:"kind" json:"kind"`
+	Unit Unit   `yaml:"unit" json:"unit"`
+	Doc  string `yaml:"doc" json:"doc"`
+}
+
+var (
+	attrMutex    sync.RWMutex
+	attrRegistry = map[string]AttrSpec{}
+)
+
+func init() {
+	for _, spec := range []AttrSpec{
+		{Key: "cpu", Kind: KindInt, Unit: UnitCores, Doc: "vertex cpu cores"},
+		{Key: "mem", Kind: KindFloat, Unit: UnitGiB, Doc: "vertex memory"},
+		{Key: "disk", Kind: KindFloat, Unit: UnitGiB, Doc: "vertex storage"},
+		{Key: "bw", Kind: KindFloat, Unit: UnitMbps, Doc: "edge bandwidth"},
+		{Key: "lat", Kind: KindFloat, Unit: UnitMs, Doc: "edge latency"},
+		{Key: "jit", Kind: KindFloat, Unit: UnitMs, Doc: "edge jitter"},
+		{Key: "distance", Kind: KindFloat, Unit: UnitKm, Doc: "edge length"},
+		{Key: "simd128", Kind: KindBool, Doc: "cpu has 128 bit simd"},
+		{Key: "simd256", Kind: KindBool, Doc: "cpu has 256 bit simd"},
+		{Key: "simd512", Kind: KindBool, Doc: "cpu has 512 bit simd"},
+		{Key: "aesni", Kind: KindBool, Doc: "cpu has aes-ni"},
+		{Key: "rdrand", Kind: KindBool, Doc: "cpu has rdrand"},
+		{Key: "vmx", Kind: KindBool, Doc: "cpu has hardware virtualization"},
+		{Key: "uuid", Kind: KindString, Doc: "edge network identifier"},
+		{Key: "name", Kind: KindString, Doc: "edge network name"},
+		{Key: "selector", Kind: KindString, Doc: "edge network selector"},
+		{Key: "owner", Kind: KindString, Doc: "administrative domain operating the resource"},
+		{Key: "trust", Kind: KindInt, Doc: "trust level of the owner, 0 is untrusted"},
+	} {
+		attrRegistry[spec.Key] = spec
+	}
+}
+
+// RegisterAttr adds a key to the attribute schema.  Registering a key again
+// with the same kind and unit is allowed.
+func RegisterAttr(spec AttrSpec) error {
+	if spec.Key == "" {
+		return fmt.Errorf("attribute key not set")
+	}
+	if spec.Unit != UnitNone {
+		if _, ok := unitScales[spec.Unit]; !ok {
+			return fmt.Errorf("unknown unit %s for attribute %s", spec.Unit, spec.Key)
+		}
+	}
+
+	attrMutex.Lock()
+	defer attrMutex.Unlock()
+
+	if old, ok := attrRegistry[spec.Key]; ok && (old.Kind != spec.Kind || old.Unit != spec.Unit) {
+		return fmt.Errorf("attribute %s already registered as %s %s", spec.Key, old.Kind, old.Unit)
+	}
+	attrRegistry[spec.Key] = spec
+
+	return nil
+}
+
+// LookupAttr returns the schema of a key
+func LookupAttr(key string) (AttrSpec, bool) {
+	attrMutex.RLock()
+	defer attrMutex.RUnlock()
+
+	spec, ok := attrRegistry[key]
+	return spec, ok
+}
+
+// Attrs returns the registered attributes sorted by key
+func Attrs() []AttrSpec {
+	attrMutex.RLock()
+	defer attrMutex.RUnlock()
+
+	specs := make([]AttrSpec, 0, len(attrRegistry))
+	for _, spec := range attrRegistry {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Key < specs[j].Key
+	})
+	return specs
+}
+
+// Value is a typed attribute value, numbers are in the unit of their key.
+type Value struct {
+	Kind Kind
+	Str  string
+	Num  float64
+	Bool bool
+	Unit Unit
+}
+
+// String returns the value as it is stored in the properties map.
+func (v Value) String() string {
+	switch v.Kind {
+	case KindInt:
+		return strconv.FormatInt(int64(v.Num), 10)
+	case KindFloat:
+		return strconv.FormatFloat(v.Num, 'f', -1, 64)
+	case KindBool:
+		return strconv.FormatBool(v.Bool)
+	}
+	return v.Str
+}
+
+// Numeric checks if the value is an int or a float
+func (v Value) Numeric() bool {
+	return v.Kind == KindInt || v.Kind == KindFloat
+}
+
+// Compare returns -1, 0 or 1 as v is less than, equal to or greater than o.
+// Ints and floats compare with each other, false is less than true.
+func (v Value) Compare(o Value) (int, error) {
+	switch {
+	case v.Numeric() && o.Numeric():
+		if v.Num < o.Num {
+			return -1, nil
+		}
+		if v.Num > o.Num {
+			return 1, nil
+		}
+		return 0, nil
+	case v.Kind == KindBool && o.Kind == KindBool:
+		if v.Bool == o.Bool {
+			return 0, nil
+		}
+		if !v.Bool {
+			return -1, nil
+		}
+		return 1, nil
+	case v.Kind == KindString && o.Kind == KindString:
+		return strings.Compare(v.Str, o.Str), nil
+	}
+
+	return 0, fmt.Errorf("%w: %s and %s", ErrAttrMismatch, v.Kind, o.Kind)
+}
+
+// ParseAttr parses a raw value for a key.  Registered numeric keys accept a
+// unit suffix (10Gbps, 5ms, 512MiB) and are converted to the key's unit.
+// Unregistered keys are floats when they parse as a number, strings
+// otherwise.
+func ParseAttr(key, raw string) (Value, error) {
+	spec, ok := LookupAttr(key)
+	if !ok {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+			return Value{Kind: KindFloat, Num: f}, nil
+		}
+		return Value{Kind: KindString, Str: raw}, nil
+	}
+
+	return parseKind(spec, raw)
+}
+
+func parseKind(spec AttrSpec, raw string) (Value, error) {
+	switch spec.Kind {
+	case KindInt, KindFloat:
+		f, err := ParseQuantity(raw, spec.Unit)
+		if err != nil {
+			return Value{}, fmt.Errorf("%w: %s: %v", ErrAttrInvalid, spec.Key, err)
+		}
+		if spec.Kind == KindInt {
+			if f != math.Trunc(f) {
+				return Value{}, fmt.Errorf("%w: %s: %s is not a whole number", ErrAttrInvalid, spec.Key, raw)
+			}
+		}
+		return Value{Kind: spec.Kind, Num: f, Unit: spec.Unit}, nil
+
+	case KindBool:
+		switch strings.ToLower(strings.TrimSpace(raw)) {
+		case "true", "yes", "1":
+			return Value{Kind: KindBool, Bool: true}, nil
+		case "false", "no", "0":
+			return Value{Kind: KindBool, Bool: false}, nil
+		}
+		return Value{}, fmt.Errorf("%w: %s: %s is not a bool", ErrAttrInvalid, spec.Key, raw)
+	}
+
+	return Value{Kind: KindString, Str: raw}, nil
+}
+
+// ParseQuantity parses a number with an optional unit suffix into unit.
+// A bare number is already in unit.
+func ParseQuantity(raw string, unit Unit) (float64, error) {
+	s := strings.TrimSpace(raw)
+
+	i := len(s)
+	for i > 0 && (unicode.IsLetter(rune(s[i-1])) || s[i-1] >= 0x80) {
+		i--
+	}
+	num, suffix := strings.TrimSpace(s[:i]), strings.ToLower(s[i:])
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("not a number: %s", raw)
+	}
+	if suffix == "" {
+		return f, nil
+	}
+
+	scale, ok := unitScales[unit][suffix]
+	if !ok {
+		if unit == UnitNone {
+			return 0, fmt.Errorf("unexpected unit %s", suffix)
+		}
+		return 0, fmt.Errorf("unit %s can not be converted to %s", suffix, unit)
+	}
+
+	return f * scale, nil
+}
+
+// GetAttr reads a typed attribute from a properties map
+func GetAttr(props map[string]string, key string) (Value, error) {
+	raw, ok := props[key]
+	if !ok {
+		return Value{}, fmt.Errorf("%w: %s", ErrAttrMissing, key)
+	}
+	return ParseAttr(key, raw)
+}
+
+// SetAttr stores a typed attribute in a properties map.  val may be a
+// Value, a string to parse, or a go int, float or bool.
+func SetAttr(props map[string]string, key string, val interface{}) error {
+	v, err := toValue(key, val)
+	if err != nil {
+		return err
+	}
+	props[key] = v.String()
+	return nil
+}
+
+// NormalizeProperties returns a copy of props with the values of registered
+// attributes in their canonical form, e.g. bw=10Gbps becomes bw=10000.
+// Unregistered keys are copied as they are.
+func NormalizeProperties(props map[string]string) (map[string]string, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	norm := make(map[string]string, len(props))
+	for k, raw := range props {
+		if _, ok := LookupAttr(k); !ok {
+			norm[k] = raw
+			continue
+		}
+		if err := SetAttr(norm, k, raw); err != nil {
+			return nil, err
+		}
+	}
+
+	return norm, nil
+}
+
+func toValue(key string, val interface{}) (Value, error) {
+	spec, registered := LookupAttr(key)
+	if !registered {
+		spec = AttrSpec{Key: key}
+	}
+
+	var v Value
+	switch x := val.(type) {
+	case Value:
+		v = x
+	case string:
+		if !registered {
+			return Value{Kind: KindString, Str: x}, nil
+		}
+		return parseKind(spec, x)
+	case int:
+		v = Value{Kind: KindInt, Num: float64(x)}
+	case int32:
+		v = Value{Kind: KindInt, Num: float64(x)}
+	case int64:
+		v = Value{Kind: KindInt, Num: float64(x)}
+	case uint32:
+		v = Value{Kind: KindInt, Num: float64(x)}
+	case uint64:
+		v = Value{Kind: KindInt, Num: float64(x)}
+	case float32:
+		v = Value{Kind: KindFloat, Num: float64(x)}
+	case float64:
+		v = Value{Kind: KindFloat, Num: x}
+	case bool:
+		v = Value{Kind: KindBool, Bool: x}
+	default:
+		return Value{}, fmt.Errorf("%w: %s: unsupported type %T", ErrAttrInvalid, key, val)
+	}
+
+	if !registered {
+		return v, nil
+	}
+
+	// numbers take the kind and unit of the key
+	switch {
+	case v.Numeric() && spec.Kind == KindInt:
+		if v.Num != math.Trunc(v.Num) {
+			return Value{}, fmt.Errorf("%w: %s: %v is not a whole number", ErrAttrInvalid, key, val)
+		}
+		v.Kind = KindInt
+	case v.Numeric() && spec.Kind == KindFloat:
+		v.Kind = KindFloat
+	case v.Kind != spec.Kind:
+		return Value{}, fmt.Errorf("%w: %s is %s, not %s", ErrAttrMismatch, key, spec.Kind, v.Kind)
+	}
+	v.Unit = spec.Unit
+
+	return v, nil
+}
+
+// Attr returns a typed vertex attribute
+func (v *Vertex) Attr(key string) (Value, error) {
+	return GetAttr(v.Properties, key)
+}
+
+// SetAttr sets a typed vertex attribute
+func (v *Vertex) SetAttr(key string, val interface{}) error {
+	if v.Properties == nil {
+		v.Properties = make(map[string]string)
+	}
+	return SetAttr(v.Properties, key, val)
+}
+
+// Attr returns a typed edge attribute
+func (e *Edge) Attr(key string) (Value, error) {
+	return GetAttr(e.Properties, key)
+}
+
+// SetAttr sets a typed edge attribute
+func (e *Edge) SetAttr(key string, val interface{}) error {
+	if e.Properties == nil {
+		e.Properties = make(map[string]string)
+	}
+	return SetAttr(e.Properties, key, val)
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttr(t *testing.T) {
+	for _, tc := range []struct {
+		key, raw string
+		want     Value
+	}{
+		{"bw", "1000", Value{Kind: KindFloat, Num: 1000, Unit: UnitMbps}},
+		{"bw", "10Gbps", Value{Kind: KindFloat, Num: 10000, Unit: UnitMbps}},
+		{"bw", "500 kbps", Value{Kind: KindFloat, Num: 0.5, Unit: UnitMbps}},
+		{"lat", "2s", Value{Kind: KindFloat, Num: 2000, Unit: UnitMs}},
+		{"mem", "512MiB", Value{Kind: KindFloat, Num: 0.5, Unit: UnitGiB}},
+		{"cpu", "16", Value{Kind: KindInt, Num: 16, Unit: UnitCores}},
+		{"distance", "100 miles", Value{Kind: KindFloat, Num: 160.9344, Unit: UnitKm}},
+		{"selector", "10", Value{Kind: KindString, Str: "10"}},
+		{"note", "2.5", Value{Kind: KindFloat, Num: 2.5}},
+		{"note", "fast", Value{Kind: KindString, Str: "fast"}},
+	} {
+		v, err := ParseAttr(tc.key, tc.raw)
+		if err != nil {
+			t.Fatalf("%s=%s: %v", tc.key, tc.raw, err)
+		}
+		assert.Equal(t, tc.want, v, "%s=%s", tc.key, tc.raw)
+	}
+
+	for _, tc := range [][2]string{{"cpu", "1.5"}, {"bw", "fast"}, {"lat", "10Mbps"}} {
+		_, err := ParseAttr(tc[0], tc[1])
+		assert.True(t, errors.Is(err, ErrAttrInvalid), "%s=%s", tc[0], tc[1])
+	}
+}
+
+func TestSetAttr(t *testing.T) {
+	props := make(map[string]string)
+
+	// the inventory hands over int64s, they print as they did before
+	assert.Nil(t, SetAttr(props, "cpu", int64(8)))
+	assert.Nil(t, SetAttr(props, "mem", int64(64)))
+	assert.Nil(t, SetAttr(props, "bw", "1Gbps"))
+	assert.Nil(t, SetAttr(props, "lat", 0.25))
+	assert.Equal(t, map[string]string{"cpu": "8", "mem": "64", "bw": "1000", "lat": "0.25"}, props)
+
+	assert.True(t, errors.Is(SetAttr(props, "cpu", 1.5), ErrAttrInvalid))
+	assert.True(t, errors.Is(SetAttr(props, "selector", 3), ErrAttrMismatch))
+
+	v, err := GetAttr(props, "bw")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	o, _ := ParseAttr("bw", "100Mbps")
+	c, err := v.Compare(o)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, c)
+
+	_, err = GetAttr(props, "jit")
+	assert.True(t, errors.Is(err, ErrAttrMissing))
+
+	s, _ := ParseAttr("selector", "x")
+	_, err = v.Compare(s)
+	assert.True(t, errors.Is(err, ErrAttrMismatch))
+}
+
+func TestRegisterAttr(t *testing.T) {
+	err := RegisterAttr(AttrSpec{Key: "secure", Kind: KindBool, Doc: "site is secured"})
+	assert.Nil(t, err)
+	assert.Nil(t, RegisterAttr(AttrSpec{Key: "secure", Kind: KindBool, Doc: "site is secured"}), "same kind again")
+	assert.NotNil(t, RegisterAttr(AttrSpec{Key: "bw", Kind: KindInt, Unit: UnitMbps}))
+	assert.NotNil(t, RegisterAttr(AttrSpec{Key: "power", Kind: KindFloat, Unit: "W"}))
+
+	v, err := ParseAttr("secure", "yes")
+	assert.Nil(t, err)
+	assert.Equal(t, Value{Kind: KindBool, Bool: true}, v)
+
+	spec, ok := LookupAttr("secure")
+	assert.True(t, ok)
+	assert.Equal(t, "site is secured", spec.Doc)
+}
+
+func TestTypedProperties(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	// units are converted on update and understood by the weights
+	err := G.UpdateEdgeProperties("ac", map[string]string{"bw": "10Gbps", "lat": "0.5s"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	e := G.GetEdgesByID("ac")[0]
+	assert.Equal(t, "10000", e.Properties["bw"])
+
+	w, err := PropertyWeight("lat")(e)
+	assert.Nil(t, err)
+	assert.Equal(t, 500.0, w)
+
+	err = G.UpdateVertexProperties("a", map[string]string{"cpu": "many"}, nil)
+	assert.True(t, errors.Is(err, ErrAttrInvalid))
+
+	// the json form is unchanged, properties are still strings
+	data, err := G.ToJson()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	J, err := FromJson([]byte(data))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "10000", J.GetEdgesByID("ac")[0].Properties["bw"])
+}
@@ -0,0 +1,376 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PropertyChange is a single property difference.  Old is empty for an added
+// property and New is empty for a removed one.
+type PropertyChange struct {
+	Key     string `yaml:"key" json:"key"`
+	Old     string `yaml:"old" json:"old"`
+	New     string `yaml:"new" json:"new"`
+	Removed bool   `yaml:"removed" json:"removed"`
+}
+
+// VertexDiff holds the property changes of a vertex.
+type VertexDiff struct {
+	Name       string            `yaml:"name" json:"name"`
+	Properties []*PropertyChange `yaml:"properties" json:"properties"`
+}
+
+// EdgeDiff holds the property changes of an edge, which is identified by its
+// id and the names of its vertices.  Index tells parallel edges with the same
+// id and vertices apart, it is the position of the edge among them.
+type EdgeDiff struct {
+	ID         string            `yaml:"id" json:"id"`
+	Vertices   []string          `yaml:"vertices" json:"vertices"`
+	Index      int               `yaml:"index,omitempty" json:"index,omitempty"`
+	Properties []*PropertyChange `yaml:"properties" json:"properties"`
+}
+
+// GraphDiff is the difference between two graphs.  Edges are matched by id
+// (uuid property or name) and vertices; an edge whose vertices change shows
+// as removed and added.  Parallel edges with the same id are matched in
+// order, so those that go are the last of them.
+type GraphDiff struct {
+	AddedVertices    []*Vertex     `yaml:"addedVertices" json:"addedVertices"`
+	RemovedVertices  []*Vertex     `yaml:"removedVertices" json:"removedVertices"`
+	ModifiedVertices []*VertexDiff `yaml:"modifiedVertices" json:"modifiedVertices"`
+	AddedEdges       []*Edge       `yaml:"addedEdges" json:"addedEdges"`
+	RemovedEdges     []*Edge       `yaml:"removedEdges" json:"removedEdges"`
+	ModifiedEdges    []*EdgeDiff   `yaml:"modifiedEdges" json:"modifiedEdges"`
+}
+
+// Diff returns the changes that turn graph a into graph b.  Either graph
+// may be nil, which is the same as an empty graph.
+func Diff(a, b *Graph) *GraphDiff {
+	if a == nil {
+		a = &Graph{}
+	}
+	if b == nil {
+		b = &Graph{}
+	}
+	a.ensureIndex()
+	b.ensureIndex()
+
+	d := &GraphDiff{
+		AddedVertices:    make([]*Vertex, 0),
+		RemovedVertices:  make([]*Vertex, 0),
+		ModifiedVertices: make([]*VertexDiff, 0),
+		AddedEdges:       make([]*Edge, 0),
+		RemovedEdges:     make([]*Edge, 0),
+		ModifiedEdges:    make([]*EdgeDiff, 0),
+	}
+
+	for _, v := range a.Vertices {
+		bv, ok := b.vertexIndex[v.Name]
+		if !ok {
+			d.RemovedVertices = append(d.RemovedVertices, v)
+			continue
+		}
+		if changes := diffProperties(v.Properties, bv.Properties); len(changes) > 0 {
+			d.ModifiedVertices = append(d.ModifiedVertices, &VertexDiff{Name: v.Name, Properties: changes})
+		}
+	}
+	for _, v := range b.Vertices {
+		if _, ok := a.vertexIndex[v.Name]; !ok {
+			d.AddedVertices = append(d.AddedVertices, v)
+		}
+	}
+
+	matched := make(map[*Edge]bool)
+	parallel := make(map[string]int)
+	for _, e := range a.Edges {
+		key := edgeKey(e)
+		index := parallel[key]
+		parallel[key]++
+
+		be := matchEdge(b.idIndex[e.ID()], e, matched)
+		if be == nil {
+			d.RemovedEdges = append(d.RemovedEdges, e)
+			continue
+		}
+		matched[be] = true
+		if changes := diffProperties(e.Properties, be.Properties); len(changes) > 0 {
+			d.ModifiedEdges = append(d.ModifiedEdges, &EdgeDiff{
+				ID:         e.ID(),
+				Vertices:   edgeVertexNames(e),
+				Index:      index,
+				Properties: changes,
+			})
+		}
+	}
+	for _, e := range b.Edges {
+		if !matched[e] {
+			d.AddedEdges = append(d.AddedEdges, e)
+		}
+	}
+
+	return d
+}
+
+// Empty checks if the diff has no changes.
+func (d *GraphDiff) Empty() bool {
+	return len(d.AddedVertices) == 0 && len(d.RemovedVertices) == 0 &&
+		len(d.ModifiedVertices) == 0 && len(d.AddedEdges) == 0 &&
+		len(d.RemovedEdges) == 0 && len(d.ModifiedEdges) == 0
+}
+
+// Apply patches the graph with a diff, so that Diff(a, b) applied to a gives
+// b.  The graph is left unchanged if any part of the diff does not apply.
+func (g *Graph) Apply(d *GraphDiff) error {
+	if g == nil {
+		return fmt.Errorf("apply called on nil graph")
+	}
+	if d == nil {
+		return nil
+	}
+
+	ng, err := g.DeepCopy()
+	if err != nil {
+		return err
+	}
+
+	// modified edges are found before any are removed, by their position
+	// among the edges with the same id and vertices
+	modified := make([]*Edge, len(d.ModifiedEdges))
+	for i, ed := range d.ModifiedEdges {
+		if len(ed.Vertices) != 2 {
+			return fmt.Errorf("edge %s does not have two vertices", ed.ID)
+		}
+		key := &Edge{Vertices: []*Vertex{{Name: ed.Vertices[0]}, {Name: ed.Vertices[1]}}}
+		modified[i] = nthEdge(ng.GetEdgesByID(ed.ID), key, ed.Index)
+		if modified[i] == nil {
+			return fmt.Errorf("%w: %s", ErrEdgeNotFound, ed.ID)
+		}
+	}
+
+	remove := make(map[*Edge]bool)
+	for _, e := range d.RemovedEdges {
+		ge := matchLastEdge(ng.GetEdgesByID(e.ID()), e, remove)
+		if ge == nil {
+			return fmt.Errorf("%w: %s", ErrEdgeNotFound, e.ID())
+		}
+		remove[ge] = true
+	}
+	ng.removeEdges(remove)
+
+	for _, v := range d.RemovedVertices {
+		if _, err := ng.RemoveVertex(v.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range d.AddedVertices {
+		err := ng.appendVertex(&Vertex{
+			Name:       v.Name,
+			Value:      v.Value,
+			Properties: copyProperties(v.Properties),
+			Weight:     v.Weight,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, v.Name)
+		}
+	}
+
+	for _, e := range d.AddedEdges {
+		if len(e.Vertices) != 2 {
+			return fmt.Errorf("edge %s does not have two vertices", e.Name)
+		}
+		verts, err := ng.edgeEndpoints(e.Vertices[0].Name, e.Vertices[1].Name)
+		if err != nil {
+			return err
+		}
+		ne := &Edge{
+			Name:       e.Name,
+			Vertices:   verts,
+			Properties: copyProperties(e.Properties),
+			Weight:     e.Weight,
+		}
+		// an edge without a uuid may be one of several parallel edges
+		if ne.Properties["uuid"] != "" && matchEdge(ng.GetEdgesByID(ne.ID()), ne, nil) != nil {
+			return fmt.Errorf("%w: %s", ErrEdgeAlreadyExists, ne.ID())
+		}
+		ng.appendEdge(ne)
+	}
+
+	for _, vd := range d.ModifiedVertices {
+		v, ok := ng.GetVertex(vd.Name)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrVertexNotFound, vd.Name)
+		}
+		v.Properties = applyProperties(v.Properties, vd.Properties)
+	}
+
+	for i, ed := range d.ModifiedEdges {
+		modified[i].Properties = applyProperties(modified[i].Properties, ed.Properties)
+	}
+
+	// ids can change with the uuid property
+	ng.reindex()
+
+	g.Name = ng.Name
+	g.Vertices = ng.Vertices
+	g.Edges = ng.Edges
+	g.reindex()
+
+	return nil
+}
+
+// String renders the diff one change per line, + for added, - for removed
+// and ~ for modified.
+func (d *GraphDiff) String() string {
+	var b strings.Builder
+
+	for _, v := range d.AddedVertices {
+		fmt.Fprintf(&b, "+ vertex %s%s\n", v.Name, formatProperties(v.Properties))
+	}
+	for _, v := range d.RemovedVertices {
+		fmt.Fprintf(&b, "- vertex %s\n", v.Name)
+	}
+	for _, vd := range d.ModifiedVertices {
+		fmt.Fprintf(&b, "~ vertex %s\n", vd.Name)
+		writeChanges(&b, vd.Properties)
+	}
+	for _, e := range d.AddedEdges {
+		fmt.Fprintf(&b, "+ edge %s (%s)%s\n", e.ID(), strings.Join(edgeVertexNames(e), " -- "), formatProperties(e.Properties))
+	}
+	for _, e := range d.RemovedEdges {
+		fmt.Fprintf(&b, "- edge %s (%s)\n", e.ID(), strings.Join(edgeVertexNames(e), " -- "))
+	}
+	for _, ed := range d.ModifiedEdges {
+		fmt.Fprintf(&b, "~ edge %s (%s)\n", ed.ID, strings.Join(ed.Vertices, " -- "))
+		writeChanges(&b, ed.Properties)
+	}
+
+	return b.String()
+}
+
+func writeChanges(b *strings.Builder, changes []*PropertyChange) {
+	for _, c := range changes {
+		switch {
+		case c.Removed:
+			fmt.Fprintf(b, "    - %s=%s\n", c.Key, c.Old)
+		case c.Old == "":
+			fmt.Fprintf(b, "    + %s=%s\n", c.Key, c.New)
+		default:
+			fmt.Fprintf(b, "    ~ %s=%s -> %s\n", c.Key, c.Old, c.New)
+		}
+	}
+}
+
+func formatProperties(props map[string]string) string {
+	if len(props) == 0 {
+		return ""
+	}
+	kvs := make([]string, 0, len(props))
+	for _, k := range sortedProps(props) {
+		kvs = append(kvs, fmt.Sprintf("%s=%s", k, props[k]))
+	}
+	return " [" + strings.Join(kvs, " ") + "]"
+}
+
+// diffProperties lists the property changes from a to b in key order.
+func diffProperties(a, b map[string]string) []*PropertyChange {
+	keys := make(map[string]string, len(a)+len(b))
+	for k := range a {
+		keys[k] = ""
+	}
+	for k := range b {
+		keys[k] = ""
+	}
+
+	changes := make([]*PropertyChange, 0)
+	for _, k := range sortedProps(keys) {
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case inA && !inB:
+			changes = append(changes, &PropertyChange{Key: k, Old: av, Removed: true})
+		case !inA && inB:
+			changes = append(changes, &PropertyChange{Key: k, New: bv})
+		case av != bv:
+			changes = append(changes, &PropertyChange{Key: k, Old: av, New: bv})
+		}
+	}
+
+	return changes
+}
+
+func applyProperties(props map[string]string, changes []*PropertyChange) map[string]string {
+	if props == nil {
+		props = make(map[string]string, len(changes))
+	}
+	for _, c := range changes {
+		if c.Removed {
+			delete(props, c.Key)
+		} else {
+			props[c.Key] = c.New
+		}
+	}
+	return props
+}
+
+func copyProperties(props map[string]string) map[string]string {
+	if props == nil {
+		return nil
+	}
+	c := make(map[string]string, len(props))
+	for k, v := range props {
+		c[k] = v
+	}
+	return c
+}
+
+// matchEdge finds the first edge in candidates joining the same vertices as
+// e that has not been used yet.
+func matchEdge(candidates []*Edge, e *Edge, used map[*Edge]bool) *Edge {
+	for _, c := range candidates {
+		if !used[c] && sameVertices(c, e) {
+			return c
+		}
+	}
+	return nil
+}
+
+// matchLastEdge is matchEdge from the end of candidates
+func matchLastEdge(candidates []*Edge, e *Edge, used map[*Edge]bool) *Edge {
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if c := candidates[i]; !used[c] && sameVertices(c, e) {
+			return c
+		}
+	}
+	return nil
+}
+
+// nthEdge returns the edge at index among the candidates joining the same
+// vertices as e
+func nthEdge(candidates []*Edge, e *Edge, index int) *Edge {
+	for _, c := range candidates {
+		if !sameVertices(c, e) {
+			continue
+		}
+		if index == 0 {
+			return c
+		}
+		index--
+	}
+	return nil
+}
+
+// edgeKey is the id and vertices parallel edges share
+func edgeKey(e *Edge) string {
+	return e.ID() + "|" + strings.Join(edgeVertexNames(e), "|")
+}
+
+func edgeVertexNames(e *Edge) []string {
+	names := make([]string, 0, len(e.Vertices))
+	for _, v := range e.Vertices {
+		names = append(names, v.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	A := testGraph(t, "test-multi")
+	B := testGraph(t, "test-multi")
+
+	d := Diff(A, B)
+	assert.True(t, d.Empty(), "same graphs")
+
+	// inventory changes: a node goes away, a link is upgraded, a network is
+	// moved and a new node joins
+	_, err := B.RemoveVertex("c")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = B.UpdateEdgeProperties("bd2", map[string]string{"lat": "1", "bw": "100"}, []string{"selector"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = B.RemoveEdge("ab1")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = B.AddEdge(&Vertex{Name: "a"}, &Vertex{Name: "d"}, map[string]string{"uuid": "ab1", "lat": "1"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = B.AddVertex("e", "", map[string]string{"cpu": "8"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = B.UpdateVertexProperties("a", map[string]string{"cpu": "4"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	d = Diff(A, B)
+	assert.False(t, d.Empty())
+	assert.Equal(t, []string{"e"}, vertexNames(&Graph{Vertices: d.AddedVertices}))
+	assert.Equal(t, []string{"c"}, vertexNames(&Graph{Vertices: d.RemovedVertices}))
+	assert.Equal(t, 1, len(d.ModifiedVertices))
+	assert.Equal(t, []string{"ab1"}, bridgeIDs(d.AddedEdges), "moved edge is added")
+	assert.Equal(t, []string{"ab1", "ac", "cd"}, bridgeIDs(d.RemovedEdges))
+
+	assert.Equal(t, 1, len(d.ModifiedEdges))
+	assert.Equal(t, "bd2", d.ModifiedEdges[0].ID)
+	assert.Equal(t, []*PropertyChange{
+		{Key: "bw", New: "100"},
+		{Key: "lat", Old: "3", New: "1"},
+		{Key: "selector", Old: "y", Removed: true},
+	}, d.ModifiedEdges[0].Properties)
+
+	out := d.String()
+	assert.Contains(t, out, "+ vertex e [cpu=8]\n")
+	assert.Contains(t, out, "- edge cd (c -- d)\n")
+	assert.Contains(t, out, "    ~ lat=3 -> 1\n")
+
+	// the reverse diff swaps additions and removals
+	r := Diff(B, A)
+	assert.Equal(t, len(d.AddedEdges), len(r.RemovedEdges))
+	assert.Equal(t, len(d.RemovedVertices), len(r.AddedVertices))
+}
+
+func TestApply(t *testing.T) {
+	A := testGraph(t, "test-multi")
+	B := testGraph(t, "test-multi")
+
+	_, err := B.RemoveVertex("b")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = B.AddEdge(&Vertex{Name: "a"}, &Vertex{Name: "e"}, map[string]string{"uuid": "ae", "bw": "10"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = B.UpdateEdgeProperties("cd", map[string]string{"lat": "9"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	d := Diff(A, B)
+
+	// a diff survives a json round trip, as the network service sends it
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	dd := &GraphDiff{}
+	err = json.Unmarshal(data, dd)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	err = A.Apply(dd)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, Diff(A, B).Empty(), "applied diff gives the target graph")
+	assert.Equal(t, "9", A.GetEdgesByID("cd")[0].Properties["lat"])
+	assert.Equal(t, 1, A.Degree("e"))
+
+	// applying it again fails and leaves the graph as it was
+	before, _ := A.ToJson()
+	err = A.Apply(dd)
+	assert.True(t, errors.Is(err, ErrEdgeNotFound))
+	after, _ := A.ToJson()
+	assert.Equal(t, before, after)
+}
+
+func TestApplyParallel(t *testing.T) {
+	// a dot load names both a -- b edges a-b, without a uuid
+	parallel := func(bws ...string) *Graph {
+		G := &Graph{}
+		for _, name := range []string{"a", "b"} {
+			_, err := G.AddVertex(name, "", nil)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+		for _, bw := range bws {
+			verts, err := G.edgeEndpoints("a", "b")
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			G.appendEdge(&Edge{Name: "a-b", Vertices: verts, Properties: map[string]string{"bw": bw}})
+		}
+		return G
+	}
+
+	for _, c := range []struct {
+		from, to []string
+	}{
+		{[]string{"1", "2"}, []string{"1", "3"}},
+		{[]string{"1", "2", "3"}, []string{"1", "5"}},
+		{[]string{"1", "2"}, []string{"4", "2", "6"}},
+	} {
+		A, B := parallel(c.from...), parallel(c.to...)
+
+		err := A.Apply(Diff(A, B))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		assert.True(t, Diff(A, B).Empty(), "%v to %v", c.from, c.to)
+
+		bws := make([]string, 0)
+		for _, e := range A.Edges {
+			bws = append(bws, e.Properties["bw"])
+		}
+		assert.Equal(t, c.to, bws)
+	}
+}
@@ -0,0 +1,504 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// FromDot reads a graph written in the Graphviz DOT language.  Node and edge
+// attributes, including those set by node and edge default statements,
+// become properties.  The key attribute names an edge, as DotViz writes it,
+// otherwise edges are named like NewEdge names them.  Edges are undirected
+// in the graph, so a digraph is read as if it were a graph.
+func FromDot(data []byte) (*Graph, error) {
+	toks, err := dotTokenize([]rune(string(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotParser{toks: toks}
+	return p.parse()
+}
+
+type dotToken struct {
+	text   string
+	quoted bool // quoted and html strings are never keywords or punctuation
+	pos    int
+}
+
+type dotParser struct {
+	toks   []dotToken
+	pos    int
+	g      *Graph
+	strict bool
+}
+
+// dotScope holds the node and edge defaults of a graph or subgraph.
+type dotScope struct {
+	node map[string]string
+	edge map[string]string
+}
+
+func (s *dotScope) child() *dotScope {
+	c := &dotScope{node: make(map[string]string), edge: make(map[string]string)}
+	for k, v := range s.node {
+		c.node[k] = v
+	}
+	for k, v := range s.edge {
+		c.edge[k] = v
+	}
+	return c
+}
+
+func (p *dotParser) peek() *dotToken {
+	if p.pos >= len(p.toks) {
+		return nil
+	}
+	return &p.toks[p.pos]
+}
+
+// is checks if the next token is the unquoted punctuation or keyword s.
+func (p *dotParser) is(s string) bool {
+	t := p.peek()
+	return t != nil && !t.quoted && strings.EqualFold(t.text, s)
+}
+
+func (p *dotParser) expect(s string) error {
+	if !p.is(s) {
+		return p.errorf("expected %s", s)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *dotParser) errorf(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if t := p.peek(); t != nil {
+		return fmt.Errorf("dot: %s at %d, found %q", msg, t.pos, t.text)
+	}
+	return fmt.Errorf("dot: %s at end of input", msg)
+}
+
+// id reads an identifier, keywords and punctuation are not identifiers.
+func (p *dotParser) id() (string, error) {
+	t := p.peek()
+	if t == nil {
+		return "", p.errorf("expected id")
+	}
+	if !t.quoted {
+		switch strings.ToLower(t.text) {
+		case "{", "}", "[", "]", "=", ";", ",", ":", "--", "->",
+			"node", "edge", "graph", "digraph", "subgraph", "strict":
+			return "", p.errorf("expected id")
+		}
+	}
+	p.pos++
+	return t.text, nil
+}
+
+func (p *dotParser) parse() (*Graph, error) {
+	if p.is("strict") {
+		p.strict = true
+		p.pos++
+	}
+	if !p.is("graph") && !p.is("digraph") {
+		return nil, p.errorf("expected graph or digraph")
+	}
+	p.pos++
+
+	p.g = &Graph{Vertices: make([]*Vertex, 0), Edges: make([]*Edge, 0)}
+	p.g.reindex()
+
+	if !p.is("{") {
+		name, err := p.id()
+		if err != nil {
+			return nil, err
+		}
+		p.g.Name = name
+	}
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	scope := &dotScope{node: make(map[string]string), edge: make(map[string]string)}
+	if _, err := p.stmtList(scope); err != nil {
+		return nil, err
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	if p.peek() != nil {
+		return nil, p.errorf("unexpected input after graph")
+	}
+
+	return p.g, nil
+}
+
+// stmtList reads statements up to the closing brace, returning the names of
+// the nodes used within it.
+func (p *dotParser) stmtList(scope *dotScope) ([]string, error) {
+	members := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(names []string) {
+		for _, n := range names {
+			if !seen[n] {
+				seen[n] = true
+				members = append(members, n)
+			}
+		}
+	}
+
+	for p.peek() != nil && !p.is("}") {
+		if p.is(";") {
+			p.pos++
+			continue
+		}
+
+		// default attribute statements
+		if p.is("graph") || p.is("node") || p.is("edge") {
+			kind := strings.ToLower(p.peek().text)
+			p.pos++
+			attrs, err := p.attrLists()
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range attrs {
+				switch kind {
+				case "node":
+					scope.node[k] = v
+				case "edge":
+					scope.edge[k] = v
+				}
+			}
+			continue
+		}
+
+		// graph attribute, a = b
+		if !p.is("{") && !p.is("subgraph") && p.pos+1 < len(p.toks) &&
+			p.toks[p.pos+1].text == "=" && !p.toks[p.pos+1].quoted {
+			p.pos += 2
+			if _, err := p.id(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		nodes, err := p.stmt(scope)
+		if err != nil {
+			return nil, err
+		}
+		add(nodes)
+	}
+
+	return members, nil
+}
+
+// stmt reads a node, edge or subgraph statement.
+func (p *dotParser) stmt(scope *dotScope) ([]string, error) {
+	groups := make([][]string, 0)
+
+	for {
+		group, err := p.operand(scope)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+
+		if !p.is("--") && !p.is("->") {
+			break
+		}
+		p.pos++
+	}
+
+	attrs := make(map[string]string)
+	if p.is("[") {
+		var err error
+		attrs, err = p.attrLists()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	members := make([]string, 0)
+	for _, group := range groups {
+		members = append(members, group...)
+	}
+
+	// a node statement sets the attributes of the node
+	if len(groups) == 1 {
+		for _, name := range groups[0] {
+			v, _ := p.g.GetVertex(name)
+			for k, val := range attrs {
+				v.Properties[k] = val
+			}
+		}
+		return members, nil
+	}
+
+	props := make(map[string]string)
+	for k, v := range scope.edge {
+		props[k] = v
+	}
+	for k, v := range attrs {
+		props[k] = v
+	}
+
+	for i := 0; i+1 < len(groups); i++ {
+		for _, src := range groups[i] {
+			for _, dst := range groups[i+1] {
+				if err := p.edge(src, dst, props); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return members, nil
+}
+
+// operand reads a node id or a subgraph, returning the nodes it names.
+func (p *dotParser) operand(scope *dotScope) ([]string, error) {
+	if p.is("subgraph") || p.is("{") {
+		if p.is("subgraph") {
+			p.pos++
+			if !p.is("{") {
+				if _, err := p.id(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.expect("{"); err != nil {
+			return nil, err
+		}
+		nodes, err := p.stmtList(scope.child())
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		return nodes, nil
+	}
+
+	name, err := p.id()
+	if err != nil {
+		return nil, err
+	}
+
+	// ports are layout only
+	for i := 0; i < 2 && p.is(":"); i++ {
+		p.pos++
+		if _, err := p.id(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := p.g.GetVertex(name); !ok {
+		props := make(map[string]string)
+		for k, v := range scope.node {
+			props[k] = v
+		}
+		if err := p.g.appendVertex(&Vertex{Name: name, Properties: props}); err != nil {
+			return nil, err
+		}
+	}
+
+	return []string{name}, nil
+}
+
+func (p *dotParser) edge(src, dst string, props map[string]string) error {
+	if src == dst {
+		return fmt.Errorf("dot: self loop on %s is not supported", src)
+	}
+
+	verts, err := p.g.edgeEndpoints(src, dst)
+	if err != nil {
+		return err
+	}
+
+	e := &Edge{
+		Name:       fmt.Sprintf("%s-%s", src, dst),
+		Vertices:   verts,
+		Properties: make(map[string]string),
+	}
+	for k, v := range props {
+		if k == "key" {
+			e.Name = v
+			continue
+		}
+		e.Properties[k] = v
+	}
+
+	// a strict graph has at most one edge between two vertices
+	if p.strict {
+		if eList, found := p.g.FindEdge(e); found {
+			for k, v := range e.Properties {
+				eList[0].Properties[k] = v
+			}
+			return nil
+		}
+	}
+
+	p.g.appendEdge(e)
+	return nil
+}
+
+// attrLists reads one or more [ a = b, ... ] lists.
+func (p *dotParser) attrLists() (map[string]string, error) {
+	attrs := make(map[string]string)
+
+	if !p.is("[") {
+		return nil, p.errorf("expected [")
+	}
+	for p.is("[") {
+		p.pos++
+		for !p.is("]") {
+			key, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			val := "true"
+			if p.is("=") {
+				p.pos++
+				val, err = p.id()
+				if err != nil {
+					return nil, err
+				}
+			}
+			attrs[key] = val
+
+			if p.is(",") || p.is(";") {
+				p.pos++
+			}
+		}
+		p.pos++
+	}
+
+	return attrs, nil
+}
+
+// dotTokenize splits DOT input into ids and punctuation, dropping comments
+// and joining concatenated strings.
+func dotTokenize(in []rune) ([]dotToken, error) {
+	toks := make([]dotToken, 0)
+
+	isIDRune := func(c rune) bool {
+		return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) || c > 0x7f
+	}
+
+	i := 0
+	for i < len(in) {
+		c := in[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '#' || (c == '/' && i+1 < len(in) && in[i+1] == '/'):
+			for i < len(in) && in[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && i+1 < len(in) && in[i+1] == '*':
+			start := i
+			for i += 2; i+1 < len(in) && !(in[i] == '*' && in[i+1] == '/'); i++ {
+			}
+			if i+1 >= len(in) {
+				return nil, fmt.Errorf("dot: unterminated comment at %d", start)
+			}
+			i += 2
+
+		case c == '"':
+			start := i
+			var b strings.Builder
+			for {
+				i++
+				for i < len(in) && in[i] != '"' {
+					if in[i] == '\\' && i+1 < len(in) {
+						switch in[i+1] {
+						case '"':
+							b.WriteRune('"')
+							i += 2
+							continue
+						case '\n':
+							i += 2
+							continue
+						}
+					}
+					b.WriteRune(in[i])
+					i++
+				}
+				if i >= len(in) {
+					return nil, fmt.Errorf("dot: unterminated string at %d", start)
+				}
+				i++
+
+				// "a" + "b" is a single string
+				j := i
+				for j < len(in) && unicode.IsSpace(in[j]) {
+					j++
+				}
+				if j >= len(in) || in[j] != '+' {
+					break
+				}
+				j++
+				for j < len(in) && unicode.IsSpace(in[j]) {
+					j++
+				}
+				if j >= len(in) || in[j] != '"' {
+					break
+				}
+				i = j
+			}
+			toks = append(toks, dotToken{text: b.String(), quoted: true, pos: start})
+
+		case c == '<':
+			start := i
+			depth := 0
+			for ; i < len(in); i++ {
+				if in[i] == '<' {
+					depth++
+				} else if in[i] == '>' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if i >= len(in) {
+				return nil, fmt.Errorf("dot: unterminated html string at %d", start)
+			}
+			i++
+			toks = append(toks, dotToken{text: string(in[start+1 : i-1]), quoted: true, pos: start})
+
+		case c == '-' && i+1 < len(in) && (in[i+1] == '-' || in[i+1] == '>'):
+			toks = append(toks, dotToken{text: string(in[i : i+2]), pos: i})
+			i += 2
+
+		case strings.ContainsRune("{}[]=;,:", c):
+			toks = append(toks, dotToken{text: string(c), pos: i})
+			i++
+
+		case c == '-' || c == '.' || unicode.IsDigit(c):
+			start := i
+			i++
+			for i < len(in) && (unicode.IsDigit(in[i]) || in[i] == '.') {
+				i++
+			}
+			toks = append(toks, dotToken{text: string(in[start:i]), quoted: true, pos: start})
+
+		case isIDRune(c):
+			start := i
+			for i < len(in) && isIDRune(in[i]) {
+				i++
+			}
+			toks = append(toks, dotToken{text: string(in[start:i]), pos: start})
+
+		default:
+			return nil, fmt.Errorf("dot: unexpected %q at %d", c, i)
+		}
+	}
+
+	return toks, nil
+}
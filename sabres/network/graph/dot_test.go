@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromDot(t *testing.T) {
+	dot := `/* test topology */
+graph "test-dot" {
+	rankdir = LR
+	node [cpu=8, mem="16"]
+	a [cpu=4]
+	b
+	// parallel networks between a and b
+	a -- b [key="a-b", uuid=ab1, bw=100, selector=x]
+	a -- b [key="a-b", uuid=ab2, bw=1000, selector=y]
+	edge [selector=x bw=10]
+	b -- c -- d
+	subgraph cluster_0 {
+		node [cpu=2]
+		e; f
+	}
+	d -- {e f} [lat=5]
+	g [note="two " + "words", html=<<b>bold</b>>]
+}`
+
+	G, err := FromDot([]byte(dot))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, "test-dot", G.Name)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g"}, vertexNames(G))
+
+	a, _ := G.GetVertex("a")
+	assert.Equal(t, map[string]string{"cpu": "4", "mem": "16"}, a.Properties)
+	e, _ := G.GetVertex("e")
+	assert.Equal(t, "2", e.Properties["cpu"], "subgraph node defaults")
+	g, _ := G.GetVertex("g")
+	assert.Equal(t, "two words", g.Properties["note"])
+	assert.Equal(t, "<b>bold</b>", g.Properties["html"])
+
+	ab := G.GetEdges("a-b")
+	assert.Equal(t, 2, len(ab), "parallel edges with their own attributes")
+	assert.Equal(t, "1000", G.GetEdgesByID("ab2")[0].Properties["bw"])
+
+	bc := G.GetEdges("b-c")
+	assert.Equal(t, 1, len(bc))
+	assert.Equal(t, map[string]string{"selector": "x", "bw": "10"}, bc[0].Properties)
+
+	de := G.GetEdges("d-e")
+	assert.Equal(t, 1, len(de), "edge to a subgraph joins every node in it")
+	assert.Equal(t, "5", de[0].Properties["lat"])
+	assert.Equal(t, 1, len(G.GetEdges("d-f")))
+
+	_, err = G.ShortestPath("a", "f", &PathOptions{Weight: PropertyWeight("bw")})
+	assert.Nil(t, err, "loaded graph is searchable")
+}
+
+func TestFromDotVariants(t *testing.T) {
+	for _, dot := range []string{
+		`graph {`,
+		`graph { a -- }`,
+		`graph { a -- a }`,
+		`graph { a [cpu=8 }`,
+		`graph { "a }`,
+		`tree { a }`,
+		`graph { a } b`,
+	} {
+		_, err := FromDot([]byte(dot))
+		assert.NotNil(t, err, "%s", dot)
+	}
+
+	G, err := FromDot([]byte(`digraph { a -> b -> c }`))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, G.Degree("b"), "digraph edges are undirected")
+
+	// the output of DotViz, with its layout attributes, reads back
+	M := testGraph(t, "test-multi")
+	out, err := M.DotViz()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	D, err := FromDot([]byte(out))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, vertexNames(M), vertexNames(D))
+	assert.Equal(t, 1, len(D.GetEdges("c-d")))
+
+	S, err := FromDot([]byte(`strict graph { c -- d [bw=10]; d -- c [lat=1] }`))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	cd := S.GetEdges("c-d")
+	assert.Equal(t, 1, len(cd), "strict graph merges repeated edges")
+	assert.Equal(t, map[string]string{"bw": "10", "lat": "1"}, cd[0].Properties)
+}
+
+func vertexNames(g *Graph) []string {
+	names := make([]string, 0, len(g.Vertices))
+	for _, v := range g.Vertices {
+		names = append(names, v.Name)
+	}
+	return names
+}
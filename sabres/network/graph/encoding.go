@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var ErrUnknownFormat = errors.New("unknown graph format")
+
+// Formats the graph can be encoded to with Encode.
+const (
+	FormatJson    = "json"
+	FormatYaml    = "yaml"
+	FormatGraphML = "graphml"
+	FormatGML     = "gml"
+	FormatDot     = "dot"
+)
+
+// Encode writes the graph in the given format, json when format is empty.
+func (g *Graph) Encode(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", FormatJson:
+		return g.ToJson()
+	case FormatYaml, "yml":
+		return g.ToYaml()
+	case FormatGraphML:
+		return g.ToGraphML()
+	case FormatGML:
+		return g.ToGML()
+	case FormatDot:
+		return g.DotViz()
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+// Decode reads a graph in the given format, json when format is empty.
+func Decode(format string, data []byte) (*Graph, error) {
+	switch strings.ToLower(format) {
+	case "", FormatJson:
+		return FromJson(data)
+	case FormatYaml, "yml":
+		return FromYaml(data)
+	case FormatGraphML:
+		return FromGraphML(data)
+	case FormatGML:
+		return FromGML(data)
+	case FormatDot, "gv":
+		return FromDot(data)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+func FromYaml(bstring []byte) (*Graph, error) {
+
+	G := &Graph{}
+	err := yaml.Unmarshal(bstring, G)
+	if err != nil {
+		return nil, err
+	}
+	err = G.checkDecoded()
+	if err != nil {
+		return nil, err
+	}
+	G.reindex()
+
+	return G, nil
+}
+
+func (g *Graph) ToYaml() (string, error) {
+	yamlG, err := yaml.Marshal(g)
+	return string(yamlG), err
+}
+
+// edgeEndpoints returns the graph's own vertices for the ends of an edge
+// read from a format that refers to vertices by id.
+func (g *Graph) edgeEndpoints(src, dst string) ([]*Vertex, error) {
+	v1, ok := g.GetVertex(src)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEdgeVertsNotFound, src)
+	}
+	v2, ok := g.GetVertex(dst)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEdgeVertsNotFound, dst)
+	}
+	return []*Vertex{v1, v2}, nil
+}
+
+// parseWeight reads a weight written by another tool, which may be a
+// double, rounded to the whole weights of the graph.
+func parseWeight(s string) (int, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("weight %s is not a number", s)
+	}
+	return int(math.Round(f)), nil
+}
+
+// setProperty sets a decoded property, making the map on first use so
+// items without properties keep a nil map.
+func setProperty(props map[string]string, key, value string) map[string]string {
+	if props == nil {
+		props = make(map[string]string)
+	}
+	props[key] = value
+	return props
+}
+
+// appendVertex adds a decoded vertex as is, keeping its weight.
+func (g *Graph) appendVertex(v *Vertex) error {
+	if _, ok := g.GetVertex(v.Name); ok {
+		return ErrVertexAlreadyExists
+	}
+	g.Vertices = append(g.Vertices, v)
+	g.indexVertex(v)
+	return nil
+}
+
+// appendEdge adds a decoded edge without the duplicate checks of AddEdge,
+// the encoded graph is trusted to be what was written.
+func (g *Graph) appendEdge(e *Edge) {
+	g.ensureIndex()
+	g.Edges = append(g.Edges, e)
+	g.indexEdge(e)
+}
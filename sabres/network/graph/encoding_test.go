@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	G := testGraph(t, "test-multi")
+	G.Edges[0].Properties["note"] = `quoted "value" & <more>`
+	G.Edges[2].Weight = 7
+
+	contents, err := ioutil.ReadFile("test_graph_1.json")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	J, err := FromJson(contents)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	for _, format := range []string{FormatJson, FormatYaml, FormatGraphML, FormatGML} {
+		for _, g := range []*Graph{G, J} {
+			out, err := g.Encode(format)
+			if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+
+			GG, err := Decode(format, []byte(out))
+			if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+
+			again, err := GG.Encode(format)
+			if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			assert.Equal(t, out, again, "%s: encoding is stable", format)
+
+			// compare through json as formats that refer to vertices by
+			// name share the vertex objects between the graph and edges
+			j1, _ := g.ToJson()
+			j2, _ := GG.ToJson()
+			assert.JSONEq(t, j1, j2, "%s: %s round trip keeps the graph", format, g.Name)
+
+			for _, e := range GG.Edges {
+				_, found := GG.FindEdge(e)
+				assert.True(t, found, "%s: decoded edges are indexed", format)
+			}
+		}
+	}
+
+	_, err = G.Encode("csv")
+	assert.True(t, errors.Is(err, ErrUnknownFormat))
+}
+
+func TestDecodeEmptyItems(t *testing.T) {
+	for _, tc := range []struct {
+		format, data string
+	}{
+		{FormatJson, `{"vertices":[null]}`},
+		{FormatJson, `{"edges":[null]}`},
+		{FormatJson, `{"vertices":[{"name":"a"}],"edges":[{"name":"e","vertices":[{"name":"a"},null]}]}`},
+		{FormatYaml, "vertices:\n- \n"},
+		{FormatYaml, "edges:\n- \n"},
+		{FormatYaml, "edges:\n- name: e\n  vertices:\n  - name: a\n  - \n"},
+		{FormatJson, `{"vertices":[{"name":""}]}`},
+		{FormatJson, `{"vertices":[{"name":"a"},{"name":"a"}]}`},
+		{FormatJson, `{"vertices":[{"name":"a"}],"edges":[{"name":"e","vertices":[{"name":"a"},{}]}]}`},
+		{FormatYaml, "vertices:\n- name: a\n- name: a\n"},
+	} {
+		_, err := Decode(tc.format, []byte(tc.data))
+		assert.True(t, errors.Is(err, ErrInvalidGraph), "%s %q: %v", tc.format, tc.data, err)
+	}
+}
+
+func TestEncodeYaml(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	out, err := G.ToYaml()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	GG, err := FromYaml([]byte(out))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, reflect.DeepEqual(G, GG), "yaml round trip keeps the graph")
+}
+
+func TestDecodeForeign(t *testing.T) {
+	graphml := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="cpu" attr.type="string"><default>4</default></key>
+  <key id="d1" for="edge" attr.name="bw" attr.type="double"/>
+  <graph id="G" edgedefault="directed">
+    <node id="n0"><data key="d0">8</data></node>
+    <node id="n1"/>
+    <node id="n2"><data key="weight">2.6</data></node>
+    <edge source="n0" target="n1"><data key="d1">100</data></edge>
+    <edge source="n1" target="n2"><data key="weight">1.0</data></edge>
+  </graph>
+</graphml>`
+
+	G, err := FromGraphML([]byte(graphml))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "G", G.Name)
+	assert.Equal(t, "8", G.Vertices[0].Properties["cpu"])
+	assert.Equal(t, "4", G.Vertices[1].Properties["cpu"], "key default applies")
+	assert.Equal(t, "n0-n1", G.Edges[0].Name)
+	assert.Equal(t, "100", G.Edges[0].Properties["bw"])
+	assert.Equal(t, 2, G.Degree("n1"))
+	assert.Equal(t, 3, G.Vertices[2].Weight, "double weights are rounded")
+	assert.Equal(t, 1, G.Edges[1].Weight)
+	assert.Nil(t, G.Edges[1].Properties, "no properties stay nil")
+
+	gml := `Creator "other tool"
+graph [
+  # a comment
+  directed 1
+  label "H"
+  node [ id 1 label "a" x 1.5 graphics [ w 10 ] ]
+  node [ id 2 weight 0.5 ]
+  edge [ source 1 target 2 bw 100 ]
+  edge [ source 2 target 1 weight 4.0 ]
+]`
+
+	H, err := FromGML([]byte(gml))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "H", H.Name)
+	assert.Equal(t, []string{"a", "2"}, []string{H.Vertices[0].Name, H.Vertices[1].Name})
+	assert.Equal(t, map[string]string{"x": "1.5"}, H.Vertices[0].Properties)
+	assert.Equal(t, "a-2", H.Edges[0].Name)
+	assert.Equal(t, "100", H.Edges[0].Properties["bw"])
+	assert.Nil(t, H.Vertices[1].Properties, "no properties stay nil")
+	assert.Equal(t, 1, H.Vertices[1].Weight)
+	assert.Equal(t, 4, H.Edges[1].Weight)
+
+	_, err = FromGML([]byte(`graph [ node [ id 1 ]`))
+	assert.NotNil(t, err, "unterminated list")
+
+	_, err = FromGML([]byte(`graph [ edge [ source 1 target 2 ] ]`))
+	assert.True(t, errors.Is(err, ErrEdgeVertsNotFound))
+
+	for _, bad := range []string{
+		`graph [ node [ label "a" ] ]`,
+		`graph [ node [ id 1 label "a" ] node [ id 1 label "b" ] ]`,
+		`graph [ node [ id 1 label "a" ] edge [ source 1 ] ]`,
+		`graph [ node [ id 1 label "a" ] edge [ target 1 label "e" ] ]`,
+	} {
+		_, err = FromGML([]byte(bad))
+		assert.True(t, errors.Is(err, ErrInvalidGraph), "%s: %v", bad, err)
+	}
+}
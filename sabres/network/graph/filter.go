@@ -0,0 +1,484 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrBadFilter = errors.New("bad filter expression")
+
+// Filter is a compiled predicate over vertex or edge properties, such as
+//
+//	bw >= 1Gbps && selector in (netA, netB)
+//	cpu >= 8 && aesni
+//
+// Comparisons are ==, !=, <, <=, > and >= (= is the same as ==), values of
+// known attributes are compared in their units.  key in (a, b) and
+// key not in (a, b) test membership, and a bare key is true when the
+// property is set to something other than false, 0 or the empty string.
+// Terms combine with &&, || and !, or and, or and not, and parentheses.
+// A comparison on a property that is not set is false.
+type Filter struct {
+	expr string
+	root filterNode
+}
+
+// ParseFilter compiles a filter expression.  The empty expression matches
+// everything.
+func ParseFilter(expr string) (*Filter, error) {
+	f := &Filter{expr: strings.TrimSpace(expr)}
+	if f.expr == "" {
+		return f, nil
+	}
+
+	toks, err := filterTokenize(f.expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{toks: toks}
+	f.root, err = p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, p.errorf("unexpected %q", p.toks[p.pos].text)
+	}
+
+	return f, nil
+}
+
+// SelectorFilter matches edges whose selector is one of sels.
+func SelectorFilter(sels ...string) *Filter {
+	if len(sels) == 0 {
+		return &Filter{}
+	}
+
+	node := &inNode{key: "selector"}
+	quoted := make([]string, 0, len(sels))
+	for _, s := range sels {
+		node.vals = append(node.vals, Value{Kind: KindString, Str: s})
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+
+	return &Filter{
+		expr: fmt.Sprintf("selector in (%s)", strings.Join(quoted, ", ")),
+		root: node,
+	}
+}
+
+// And combines two filters, either may be nil.
+func (f *Filter) And(o *Filter) *Filter {
+	switch {
+	case f.Empty():
+		return o
+	case o.Empty():
+		return f
+	}
+	return &Filter{
+		expr: fmt.Sprintf("(%s) && (%s)", f.expr, o.expr),
+		root: &andNode{l: f.root, r: o.root},
+	}
+}
+
+// Empty checks if the filter matches everything.
+func (f *Filter) Empty() bool {
+	return f == nil || f.root == nil
+}
+
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expr
+}
+
+// Match evaluates the filter against a properties map.
+func (f *Filter) Match(props map[string]string) bool {
+	if f.Empty() {
+		return true
+	}
+	return f.root.match(props)
+}
+
+// MatchVertex evaluates the filter against the vertex properties.
+func (f *Filter) MatchVertex(v *Vertex) bool {
+	return f.Match(v.Properties)
+}
+
+// MatchEdge evaluates the filter against the edge properties.
+func (f *Filter) MatchEdge(e *Edge) bool {
+	return f.Match(e.Properties)
+}
+
+// Subgraph returns a copy of the graph holding the vertices that match the
+// vertex filter and the edges that match the edge filter between them.
+// Either filter may be nil to keep everything.
+func (g *Graph) Subgraph(vertices, edges *Filter) (*Graph, error) {
+	if g == nil {
+		return nil, fmt.Errorf("subgraph called on nil graph")
+	}
+
+	ng, err := g.DeepCopy()
+	if err != nil {
+		return nil, err
+	}
+	ng.ensureIndex()
+
+	keep := make(map[string]bool, len(ng.Vertices))
+	vList := make([]*Vertex, 0, len(ng.Vertices))
+	for _, v := range ng.Vertices {
+		if vertices.MatchVertex(v) {
+			keep[v.Name] = true
+			vList = append(vList, v)
+		}
+	}
+
+	remove := make(map[*Edge]bool)
+	for _, e := range ng.Edges {
+		if !edges.MatchEdge(e) {
+			remove[e] = true
+			continue
+		}
+		for _, v := range e.Vertices {
+			if !keep[v.Name] {
+				remove[e] = true
+			}
+		}
+	}
+	ng.removeEdges(remove)
+
+	ng.Vertices = vList
+	ng.reindex()
+
+	log.Debugf("subgraph [%s] [%s]: %d vertices, %d edges\n", vertices, edges, len(ng.Vertices), len(ng.Edges))
+
+	return ng, nil
+}
+
+type filterNode interface {
+	match(props map[string]string) bool
+}
+
+type andNode struct{ l, r filterNode }
+type orNode struct{ l, r filterNode }
+type notNode struct{ n filterNode }
+
+func (n *andNode) match(props map[string]string) bool { return n.l.match(props) && n.r.match(props) }
+func (n *orNode) match(props map[string]string) bool  { return n.l.match(props) || n.r.match(props) }
+func (n *notNode) match(props map[string]string) bool { return !n.n.match(props) }
+
+// cmpNode compares a property with a value
+type cmpNode struct {
+	key string
+	op  string
+	val Value
+}
+
+func (n *cmpNode) match(props map[string]string) bool {
+	pv, err := GetAttr(props, n.key)
+	if err != nil {
+		return false
+	}
+
+	// values of different kinds are never equal and have no order
+	c, err := pv.Compare(n.val)
+	if err != nil {
+		return n.op == "!="
+	}
+
+	switch n.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// inNode checks if a property is one of a list of values
+type inNode struct {
+	key    string
+	vals   []Value
+	negate bool
+}
+
+func (n *inNode) match(props map[string]string) bool {
+	pv, err := GetAttr(props, n.key)
+	if err != nil {
+		return false
+	}
+
+	for _, v := range n.vals {
+		if c, err := pv.Compare(v); err == nil && c == 0 {
+			return !n.negate
+		}
+	}
+	return n.negate
+}
+
+// hasNode is a bare key, true when the property is set and not false
+type hasNode struct {
+	key string
+}
+
+func (n *hasNode) match(props map[string]string) bool {
+	pv, err := GetAttr(props, n.key)
+	if err != nil {
+		return false
+	}
+
+	switch pv.Kind {
+	case KindBool:
+		return pv.Bool
+	case KindInt, KindFloat:
+		return pv.Num != 0
+	}
+	return pv.Str != "" && !strings.EqualFold(pv.Str, "false")
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+	pos    int
+}
+
+type filterParser struct {
+	toks []filterToken
+	pos  int
+}
+
+func (p *filterParser) errorf(format string, args ...interface{}) error {
+	at := "end of input"
+	if p.pos < len(p.toks) {
+		at = fmt.Sprintf("%d", p.toks[p.pos].pos)
+	}
+	return fmt.Errorf("%w: %s at %s", ErrBadFilter, fmt.Sprintf(format, args...), at)
+}
+
+// is checks if the next token is the unquoted operator or keyword s
+func (p *filterParser) is(s ...string) bool {
+	if p.pos >= len(p.toks) || p.toks[p.pos].quoted {
+		return false
+	}
+	for _, x := range s {
+		if strings.EqualFold(p.toks[p.pos].text, x) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *filterParser) or() (filterNode, error) {
+	l, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("||", "or") {
+		p.pos++
+		r, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l = &orNode{l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *filterParser) and() (filterNode, error) {
+	l, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("&&", "and") {
+		p.pos++
+		r, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		l = &andNode{l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *filterParser) not() (filterNode, error) {
+	if p.is("!", "not") {
+		p.pos++
+		n, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{n: n}, nil
+	}
+	return p.term()
+}
+
+func (p *filterParser) term() (filterNode, error) {
+	if p.is("(") {
+		p.pos++
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.is(")") {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return n, nil
+	}
+
+	key, err := p.word("property")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.is("==", "=", "!=", "<", "<=", ">", ">="):
+		op := p.toks[p.pos].text
+		if op == "=" {
+			op = "=="
+		}
+		p.pos++
+		val, err := p.value(key)
+		if err != nil {
+			return nil, err
+		}
+		return &cmpNode{key: key, op: op, val: val}, nil
+
+	case p.is("in"):
+		p.pos++
+		return p.in(key, false)
+
+	case p.is("not") && p.pos+1 < len(p.toks) && !p.toks[p.pos+1].quoted &&
+		strings.EqualFold(p.toks[p.pos+1].text, "in"):
+		p.pos += 2
+		return p.in(key, true)
+	}
+
+	return &hasNode{key: key}, nil
+}
+
+func (p *filterParser) in(key string, negate bool) (filterNode, error) {
+	if !p.is("(") {
+		return nil, p.errorf("expected (")
+	}
+	p.pos++
+
+	n := &inNode{key: key, negate: negate}
+	for !p.is(")") {
+		val, err := p.value(key)
+		if err != nil {
+			return nil, err
+		}
+		n.vals = append(n.vals, val)
+
+		if p.is(",") {
+			p.pos++
+		} else if !p.is(")") {
+			return nil, p.errorf("expected , or )")
+		}
+	}
+	p.pos++
+
+	if len(n.vals) == 0 {
+		return nil, p.errorf("empty list for %s", key)
+	}
+
+	return n, nil
+}
+
+// word reads a key or value, operators are not words
+func (p *filterParser) word(what string) (string, error) {
+	if p.pos >= len(p.toks) {
+		return "", p.errorf("expected %s", what)
+	}
+	t := p.toks[p.pos]
+	if !t.quoted && strings.ContainsAny(t.text[:1], "()!=<>&|,") {
+		return "", p.errorf("expected %s, found %q", what, t.text)
+	}
+	p.pos++
+	return t.text, nil
+}
+
+// value reads a literal and types it by the key it is compared with
+func (p *filterParser) value(key string) (Value, error) {
+	raw, err := p.word("value")
+	if err != nil {
+		return Value{}, err
+	}
+	v, err := ParseAttr(key, raw)
+	if err != nil {
+		return Value{}, fmt.Errorf("%w: %v", ErrBadFilter, err)
+	}
+	return v, nil
+}
+
+var filterOperators = map[string]bool{
+	"&&": true, "||": true, "==": true, "!=": true, "<=": true, ">=": true,
+}
+
+func filterTokenize(in string) ([]filterToken, error) {
+	toks := make([]filterToken, 0)
+	rs := []rune(in)
+
+	isWordRune := func(c rune) bool {
+		return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_-.:/@+", c) || c > 0x7f
+	}
+
+	i := 0
+	for i < len(rs) {
+		c := rs[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '"' || c == '\'':
+			start := i
+			var b strings.Builder
+			for i++; i < len(rs) && rs[i] != c; i++ {
+				if rs[i] == '\\' && i+1 < len(rs) {
+					i++
+				}
+				b.WriteRune(rs[i])
+			}
+			if i >= len(rs) {
+				return nil, fmt.Errorf("%w: unterminated string at %d", ErrBadFilter, start)
+			}
+			i++
+			toks = append(toks, filterToken{text: b.String(), quoted: true, pos: start})
+
+		case i+1 < len(rs) && filterOperators[string(rs[i:i+2])]:
+			toks = append(toks, filterToken{text: string(rs[i : i+2]), pos: i})
+			i += 2
+
+		case strings.ContainsRune("()!<>=,", c):
+			toks = append(toks, filterToken{text: string(c), pos: i})
+			i++
+
+		case isWordRune(c):
+			start := i
+			for i < len(rs) && isWordRune(rs[i]) {
+				i++
+			}
+			toks = append(toks, filterToken{text: string(rs[start:i]), pos: start})
+
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at %d", ErrBadFilter, c, i)
+		}
+	}
+
+	return toks, nil
+}
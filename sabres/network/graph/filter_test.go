@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	edge := map[string]string{"bw": "1000", "lat": "5", "selector": "netA", "uuid": "e1"}
+	vertex := map[string]string{"cpu": "16", "mem": "64", "aesni": "true", "vmx": "false"}
+
+	for _, tc := range []struct {
+		expr  string
+		props map[string]string
+		want  bool
+	}{
+		{"", edge, true},
+		{"bw >= 1000 && selector in (netA, netB)", edge, true},
+		{"bw >= 1Gbps", edge, true},
+		{"bw > 1Gbps", edge, false},
+		{"lat < 0.01s", edge, true},
+		{"selector not in (netA, netB)", edge, false},
+		{"selector = 'netA' || bw < 10", edge, true},
+		{"!(selector == netA)", edge, false},
+		{"jit < 10", edge, false},
+		{"cpu >= 8 && aesni", vertex, true},
+		{"cpu >= 8 and vmx", vertex, false},
+		{"cpu >= 8 and not vmx", vertex, true},
+		{"mem >= 65536MiB", vertex, true},
+		{"disk", vertex, false},
+		{"cpu > 4 && (mem < 32 || aesni)", vertex, true},
+	} {
+		f, err := ParseFilter(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		assert.Equal(t, tc.want, f.Match(tc.props), tc.expr)
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, expr := range []string{
+		"bw >=",
+		"bw >= fast",
+		"cpu == 1.5",
+		"(bw > 10",
+		"selector in netA",
+		"selector in ()",
+		"bw > 10 bw",
+		"name == 'open",
+		"&& bw",
+		"aesni == maybe",
+	} {
+		_, err := ParseFilter(expr)
+		assert.True(t, errors.Is(err, ErrBadFilter), "%s: %v", expr, err)
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	G := testGraph(t, "test-multi")
+	for _, name := range []string{"a", "b", "c", "d"} {
+		cpu := "4"
+		if name == "c" {
+			cpu = "1"
+		}
+		err := G.UpdateVertexProperties(name, map[string]string{"cpu": cpu}, nil)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	vf, err := ParseFilter("cpu >= 2")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ef, err := ParseFilter("selector == x")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	S, err := G.Subgraph(vf, ef)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "b", "d"}, vertexNames(S))
+	assert.Equal(t, []string{"ab1", "bd1"}, bridgeIDs(S.Edges))
+	assert.Equal(t, 1, S.Degree("a"))
+	assert.Equal(t, 6, len(G.Edges), "original graph is unchanged")
+
+	// several selectors, which PruneGraph can not do
+	S, err = G.Subgraph(nil, SelectorFilter("x", "y"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, len(G.Edges), len(S.Edges))
+
+	P, err := PruneGraph(G, "y")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ab2", "bd2"}, bridgeIDs(P.Edges))
+}
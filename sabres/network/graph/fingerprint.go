@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// canonical forms of vertices and edges for Fingerprint, json sorts the
+// property keys.
+type fingerprintVertex struct {
+	Name       string            `json:"n"`
+	Value      string            `json:"v"`
+	Properties map[string]string `json:"p"`
+}
+
+type fingerprintEdge struct {
+	Vertices   [2]string         `json:"v"`
+	Properties map[string]string `json:"p"`
+}
+
+// Fingerprint returns a sha256 hash over the vertices, edges and properties
+// of the graph.  It does not depend on the order vertices and edges were
+// added, the order of the edge ends, or the graph and edge names (edge names
+// follow the order of the ends, the uuid property identifies an edge), so
+// two graphs with the same fingerprint have the same topology.
+func (g *Graph) Fingerprint() (string, error) {
+	vertices := make([]string, 0, len(g.Vertices))
+	for _, v := range g.Vertices {
+		data, err := json.Marshal(&fingerprintVertex{
+			Name:       v.Name,
+			Value:      v.Value,
+			Properties: fingerprintProperties(v.Properties),
+		})
+		if err != nil {
+			return "", err
+		}
+		vertices = append(vertices, string(data))
+	}
+	sort.Strings(vertices)
+
+	edges := make([]string, 0, len(g.Edges))
+	for _, e := range g.Edges {
+		if len(e.Vertices) != 2 {
+			return "", ErrEdgeVertsNotFound
+		}
+
+		fe := &fingerprintEdge{
+			Vertices:   [2]string{e.Vertices[0].Name, e.Vertices[1].Name},
+			Properties: fingerprintProperties(e.Properties),
+		}
+		if fe.Vertices[1] < fe.Vertices[0] {
+			fe.Vertices[0], fe.Vertices[1] = fe.Vertices[1], fe.Vertices[0]
+		}
+
+		data, err := json.Marshal(fe)
+		if err != nil {
+			return "", err
+		}
+		edges = append(edges, string(data))
+	}
+	sort.Strings(edges)
+
+	data, err := json.Marshal([][]string{vertices, edges})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fingerprintProperties treats no properties and empty properties the same.
+func fingerprintProperties(props map[string]string) map[string]string {
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	A := testGraph(t, "test-multi")
+	fp, err := A.Fingerprint()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 64, len(fp))
+
+	// the same edges added backwards and in another order
+	B := &Graph{Name: "other"}
+	for i := len(A.Edges) - 1; i >= 0; i-- {
+		e := A.Edges[i]
+		props := make(map[string]string)
+		for k, v := range e.Properties {
+			props[k] = v
+		}
+		_, err := B.AddEdge(&Vertex{Name: e.Vertices[1].Name}, &Vertex{Name: e.Vertices[0].Name}, props)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	fpB, err := B.Fingerprint()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, fp, fpB)
+
+	C, err := A.DeepCopy()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fpC, _ := C.Fingerprint()
+	assert.Equal(t, fp, fpC)
+
+	// any property, vertex or edge change is a new fingerprint
+	err = C.UpdateEdgeProperties("bd2", map[string]string{"lat": "4"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fpC, _ = C.Fingerprint()
+	assert.NotEqual(t, fp, fpC)
+
+	err = B.UpdateVertexProperties("a", map[string]string{"cpu": "4"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fpB, _ = B.Fingerprint()
+	assert.NotEqual(t, fp, fpB)
+
+	_, err = A.AddVertex("e", "", nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fpA, _ := A.Fingerprint()
+	assert.NotEqual(t, fp, fpA)
+}
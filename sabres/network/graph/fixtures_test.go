@@ -0,0 +1,114 @@
+package graph
+
+import "testing"
+
+// testEdge is an edge of a test graph between the vertices named v1 and v2,
+// one without v2 is a vertex with no edges.
+type testEdge struct {
+	v1, v2 string
+	prop   map[string]string
+}
+
+// testGraphs are the graphs the tests are run on, by name
+var testGraphs = map[string][]testEdge{
+	// diamond graph with a long direct edge between a and d
+	//
+	//	  b
+	//	 / \
+	//	a---d
+	//	 \ /
+	//	  c
+	"test-path": {
+		{"a", "b", map[string]string{"uuid": "ab", "lat": "1", "bw": "100", "jit": "3"}},
+		{"b", "d", map[string]string{"uuid": "bd", "lat": "1", "bw": "100", "jit": "3"}},
+		{"a", "c", map[string]string{"uuid": "ac", "lat": "5", "bw": "1000", "jit": "1"}},
+		{"c", "d", map[string]string{"uuid": "cd", "lat": "5", "bw": "1000", "jit": "1"}},
+		{"a", "d", map[string]string{"uuid": "ad", "lat": "20", "bw": "10", "jit": "9"}},
+	},
+
+	// diamond a-b-d, a-c-d with two networks, x and y, between a and b and
+	// between b and d
+	"test-multi": {
+		{"a", "b", map[string]string{"uuid": "ab1", "lat": "1", "selector": "x"}},
+		{"a", "b", map[string]string{"uuid": "ab2", "lat": "2", "selector": "y"}},
+		{"b", "d", map[string]string{"uuid": "bd1", "lat": "1", "selector": "x"}},
+		{"b", "d", map[string]string{"uuid": "bd2", "lat": "3", "selector": "y"}},
+		{"a", "c", map[string]string{"uuid": "ac", "lat": "2", "selector": "x"}},
+		{"c", "d", map[string]string{"uuid": "cd", "lat": "2", "selector": "x"}},
+	},
+
+	// a triangle a-b-c joined to a parallel pair d=e by the single link
+	// c-d, and an isolated vertex f
+	"test-analysis": {
+		{"a", "b", map[string]string{"uuid": "ab", "selector": "x"}},
+		{"b", "c", map[string]string{"uuid": "bc", "selector": "x"}},
+		{"c", "a", map[string]string{"uuid": "ca", "selector": "x"}},
+		{"c", "d", map[string]string{"uuid": "cd", "selector": "x"}},
+		{"d", "e", map[string]string{"uuid": "de1", "selector": "y"}},
+		{"d", "e", map[string]string{"uuid": "de2", "selector": "y"}},
+		{"f", "", nil},
+	},
+
+	// diamond a-b-d, a-c-d with two parallel networks between a and b
+	"test-flow": {
+		{"a", "b", map[string]string{"uuid": "ab1", "bw": "4", "selector": "x"}},
+		{"a", "b", map[string]string{"uuid": "ab2", "bw": "6", "selector": "y"}},
+		{"b", "d", map[string]string{"uuid": "bd", "bw": "8", "selector": "x"}},
+		{"a", "c", map[string]string{"uuid": "ac", "bw": "4", "selector": "x"}},
+		{"c", "d", map[string]string{"uuid": "cd", "bw": "6", "selector": "x"}},
+	},
+
+	// terminals a, b and c joined to each other at cost 1.8 and to the hub
+	// s at cost 1, so the minimum tree goes through s
+	"test-star": {
+		{"a", "b", map[string]string{"uuid": "ab", "lat": "1.8"}},
+		{"b", "c", map[string]string{"uuid": "bc", "lat": "1.8"}},
+		{"a", "c", map[string]string{"uuid": "ac", "lat": "1.8"}},
+		{"a", "s", map[string]string{"uuid": "as", "lat": "1"}},
+		{"b", "s", map[string]string{"uuid": "bs", "lat": "1"}},
+		{"c", "s", map[string]string{"uuid": "cs", "lat": "1"}},
+		{"c", "d", map[string]string{"uuid": "cd", "lat": "5"}},
+	},
+}
+
+// testGraph builds the named graph of testGraphs.  The edges share the
+// vertices of the graph, and every graph built has its own properties to
+// change.
+func testGraph(t *testing.T, name string) *Graph {
+	edges, ok := testGraphs[name]
+	if !ok {
+		t.Fatalf("no test graph %s", name)
+	}
+
+	G := &Graph{Name: name}
+	verts := make(map[string]*Vertex)
+	vertex := func(name string) *Vertex {
+		v, ok := verts[name]
+		if !ok {
+			v = &Vertex{Name: name}
+			verts[name] = v
+		}
+		return v
+	}
+
+	for _, e := range edges {
+		if e.v2 == "" {
+			_, err := G.AddVertex(e.v1, "", nil)
+			if err != nil {
+				t.Fatalf("Failed to add vertex %s: %v\n", e.v1, err)
+			}
+			continue
+		}
+
+		prop := make(map[string]string, len(e.prop))
+		for k, v := range e.prop {
+			prop[k] = v
+		}
+		_, err := G.AddEdge(vertex(e.v1), vertex(e.v2), prop)
+		if err != nil {
+			t.Fatalf("Failed to add edge %s-%s: %v\n", e.v1, e.v2, err)
+		}
+	}
+
+	return G
+}
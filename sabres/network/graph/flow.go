@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"math"
+)
+
+const flowEpsilon = 1e-9
+
+// flowArc is a directed arc in a residual network.  Arcs are stored in
+// pairs so that arc i^1 is always the reverse of arc i.
+type flowArc struct {
+	from int
+	to   int
+	cap  float64
+	cost float64
+	flow float64
+	edge *Edge
+}
+
+func (a *flowArc) residual() float64 {
+	return a.cap - a.flow
+}
+
+// flowNetwork is the residual network shared by the flow based algorithms,
+// nodes are dense integers and each node keeps the indices of its arcs.
+type flowNetwork struct {
+	arcs []*flowArc
+	out  [][]int
+}
+
+func newFlowNetwork(nodes int) *flowNetwork {
+	return &flowNetwork{
+		arcs: make([]*flowArc, 0),
+		out:  make([][]int, nodes),
+	}
+}
+
+// addArc adds a u->v arc with capacity and cost along with its zero
+// capacity reverse arc.  The index of the forward arc is returned.
+func (n *flowNetwork) addArc(u, v int, capacity, cost float64, e *Edge) int {
+	i := len(n.arcs)
+	n.arcs = append(n.arcs,
+		&flowArc{from: u, to: v, cap: capacity, cost: cost, edge: e},
+		&flowArc{from: v, to: u, cap: 0, cost: -cost, edge: e},
+	)
+	n.out[u] = append(n.out[u], i)
+	n.out[v] = append(n.out[v], i+1)
+	return i
+}
+
+func (n *flowNetwork) push(i int, f float64) {
+	n.arcs[i].flow += f
+	n.arcs[i^1].flow -= f
+}
+
+// minCostFlow sends up to limit units of flow from s to t along successive
+// cheapest augmenting paths, returning the flow and its total cost.
+func (n *flowNetwork) minCostFlow(s, t int, limit float64) (float64, float64) {
+	total, totalCost := 0.0, 0.0
+	nodes := len(n.out)
+
+	for total < limit-flowEpsilon {
+		// bellman-ford (spfa), residual arcs may have negative cost
+		dist := make([]float64, nodes)
+		prev := make([]int, nodes)
+		inQueue := make([]bool, nodes)
+		for i := range dist {
+			dist[i] = math.Inf(1)
+			prev[i] = -1
+		}
+		dist[s] = 0
+		queue := []int{s}
+		inQueue[s] = true
+
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			inQueue[u] = false
+
+			for _, i := range n.out[u] {
+				a := n.arcs[i]
+				if a.residual() <= flowEpsilon {
+					continue
+				}
+				if d := dist[u] + a.cost; d < dist[a.to]-flowEpsilon {
+					dist[a.to] = d
+					prev[a.to] = i
+					if !inQueue[a.to] {
+						queue = append(queue, a.to)
+						inQueue[a.to] = true
+					}
+				}
+			}
+		}
+
+		if math.IsInf(dist[t], 1) {
+			break
+		}
+
+		f := limit - total
+		for v := t; v != s; v = n.arcs[prev[v]].from {
+			f = math.Min(f, n.arcs[prev[v]].residual())
+		}
+		for v := t; v != s; v = n.arcs[prev[v]].from {
+			n.push(prev[v], f)
+		}
+
+		total += f
+		totalCost += f * dist[t]
+	}
+
+	return total, totalCost
+}
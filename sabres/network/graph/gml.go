@@ -0,0 +1,340 @@
+package graph
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// gmlPair is a key and its value in a GML list, value is a string for
+// scalars and a []*gmlPair for nested lists.
+type gmlPair struct {
+	key   string
+	value interface{}
+}
+
+// ToGML writes the graph as an undirected GML document.  GML keys are
+// limited to letters and digits, so properties are written as property
+// lists of key and value.
+func (g *Graph) ToGML() (string, error) {
+	var b strings.Builder
+
+	ids := make(map[string]int, len(g.Vertices))
+
+	b.WriteString("graph [\n")
+	fmt.Fprintf(&b, "  name %s\n", gmlString(g.Name))
+	b.WriteString("  directed 0\n")
+
+	for i, v := range g.Vertices {
+		ids[v.Name] = i
+		b.WriteString("  node [\n")
+		fmt.Fprintf(&b, "    id %d\n", i)
+		fmt.Fprintf(&b, "    label %s\n", gmlString(v.Name))
+		if v.Value != "" {
+			fmt.Fprintf(&b, "    value %s\n", gmlString(v.Value))
+		}
+		if v.Weight != 0 {
+			fmt.Fprintf(&b, "    weight %d\n", v.Weight)
+		}
+		writeGMLProperties(&b, v.Properties)
+		b.WriteString("  ]\n")
+	}
+
+	for _, e := range g.Edges {
+		if len(e.Vertices) != 2 {
+			return "", fmt.Errorf("edge %s does not have two vertices", e.Name)
+		}
+		src, ok := ids[e.Vertices[0].Name]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrEdgeVertsNotFound, e.Vertices[0].Name)
+		}
+		dst, ok := ids[e.Vertices[1].Name]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrEdgeVertsNotFound, e.Vertices[1].Name)
+		}
+
+		b.WriteString("  edge [\n")
+		fmt.Fprintf(&b, "    source %d\n", src)
+		fmt.Fprintf(&b, "    target %d\n", dst)
+		fmt.Fprintf(&b, "    label %s\n", gmlString(e.Name))
+		if e.Weight != 0 {
+			fmt.Fprintf(&b, "    weight %d\n", e.Weight)
+		}
+		writeGMLProperties(&b, e.Properties)
+		b.WriteString("  ]\n")
+	}
+
+	b.WriteString("]\n")
+
+	return b.String(), nil
+}
+
+func writeGMLProperties(b *strings.Builder, props map[string]string) {
+	for _, k := range sortedProps(props) {
+		fmt.Fprintf(b, "    property [ key %s value %s ]\n", gmlString(k), gmlString(props[k]))
+	}
+}
+
+func gmlString(s string) string {
+	return `"` + html.EscapeString(s) + `"`
+}
+
+// FromGML reads a GML graph.  Node and edge attributes written by other
+// tools, other than ids and labels, become properties.  Every node needs an
+// id, and every edge the ids of its source and target.
+func FromGML(data []byte) (*Graph, error) {
+	p := &gmlParser{input: []rune(string(data))}
+	top, err := p.list(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var graphList []*gmlPair
+	for _, kv := range top {
+		if l, ok := kv.value.([]*gmlPair); ok && kv.key == "graph" {
+			graphList = l
+			break
+		}
+	}
+	if graphList == nil {
+		return nil, fmt.Errorf("gml: no graph found")
+	}
+
+	G := &Graph{Vertices: make([]*Vertex, 0), Edges: make([]*Edge, 0)}
+	G.reindex()
+
+	names := make(map[string]string)
+
+	for _, kv := range graphList {
+		switch kv.key {
+		case "name", "label":
+			if s, ok := kv.value.(string); ok && G.Name == "" {
+				G.Name = s
+			}
+		case "node":
+			l, ok := kv.value.([]*gmlPair)
+			if !ok {
+				return nil, fmt.Errorf("gml: node is not a list")
+			}
+
+			v := &Vertex{}
+			id := ""
+			for _, a := range l {
+				switch a.key {
+				case "id":
+					id = gmlScalar(a)
+				case "label":
+					v.Name = gmlScalar(a)
+				case "value":
+					v.Value = gmlScalar(a)
+				case "weight":
+					v.Weight, err = parseWeight(gmlScalar(a))
+					if err != nil {
+						return nil, fmt.Errorf("gml: node %s weight: %v", id, err)
+					}
+				default:
+					v.Properties = gmlProperty(v.Properties, a)
+				}
+			}
+			if id == "" {
+				return nil, fmt.Errorf("gml: %w: node %s has no id", ErrInvalidGraph, v.Name)
+			}
+			if _, ok := names[id]; ok {
+				return nil, fmt.Errorf("gml: %w: node id %s is used twice", ErrInvalidGraph, id)
+			}
+			if v.Name == "" {
+				v.Name = id
+			}
+			names[id] = v.Name
+
+			err = G.appendVertex(v)
+			if err != nil {
+				return nil, fmt.Errorf("gml: node %s: %w", v.Name, err)
+			}
+		}
+	}
+
+	for _, kv := range graphList {
+		if kv.key != "edge" {
+			continue
+		}
+		l, ok := kv.value.([]*gmlPair)
+		if !ok {
+			return nil, fmt.Errorf("gml: edge is not a list")
+		}
+
+		e := &Edge{}
+		srcID, dstID := "", ""
+		for _, a := range l {
+			switch a.key {
+			case "source":
+				srcID = gmlScalar(a)
+			case "target":
+				dstID = gmlScalar(a)
+			case "label", "name":
+				e.Name = gmlScalar(a)
+			case "weight":
+				e.Weight, err = parseWeight(gmlScalar(a))
+				if err != nil {
+					return nil, fmt.Errorf("gml: edge weight: %v", err)
+				}
+			default:
+				e.Properties = gmlProperty(e.Properties, a)
+			}
+		}
+
+		if srcID == "" || dstID == "" {
+			return nil, fmt.Errorf("gml: %w: edge %s needs a source and a target", ErrInvalidGraph, e.Name)
+		}
+		src, ok := names[srcID]
+		if !ok {
+			return nil, fmt.Errorf("gml: %w: no node with id %s", ErrEdgeVertsNotFound, srcID)
+		}
+		dst, ok := names[dstID]
+		if !ok {
+			return nil, fmt.Errorf("gml: %w: no node with id %s", ErrEdgeVertsNotFound, dstID)
+		}
+
+		verts, err := G.edgeEndpoints(src, dst)
+		if err != nil {
+			return nil, fmt.Errorf("gml: %w", err)
+		}
+		e.Vertices = verts
+		if e.Name == "" {
+			e.Name = fmt.Sprintf("%s-%s", src, dst)
+		}
+
+		G.appendEdge(e)
+	}
+
+	return G, nil
+}
+
+func gmlScalar(kv *gmlPair) string {
+	if s, ok := kv.value.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// gmlProperty adds a property list, or any other scalar attribute, to props
+// and returns them.  Nested lists such as graphics are skipped.
+func gmlProperty(props map[string]string, kv *gmlPair) map[string]string {
+	l, ok := kv.value.([]*gmlPair)
+	if !ok {
+		return setProperty(props, kv.key, gmlScalar(kv))
+	}
+	if kv.key != "property" {
+		return props
+	}
+
+	key, val := "", ""
+	for _, a := range l {
+		switch a.key {
+		case "key":
+			key = gmlScalar(a)
+		case "value":
+			val = gmlScalar(a)
+		}
+	}
+	if key != "" {
+		props = setProperty(props, key, val)
+	}
+	return props
+}
+
+type gmlParser struct {
+	input []rune
+	pos   int
+}
+
+// list reads key value pairs until the end of input, or the closing
+// bracket when nested.
+func (p *gmlParser) list(nested bool) ([]*gmlPair, error) {
+	pairs := make([]*gmlPair, 0)
+
+	for {
+		p.skip()
+		if p.pos >= len(p.input) {
+			if nested {
+				return nil, fmt.Errorf("gml: missing ]")
+			}
+			return pairs, nil
+		}
+		if p.input[p.pos] == ']' {
+			if !nested {
+				return nil, fmt.Errorf("gml: unexpected ] at %d", p.pos)
+			}
+			p.pos++
+			return pairs, nil
+		}
+
+		key := p.key()
+		if key == "" {
+			return nil, fmt.Errorf("gml: expected key at %d", p.pos)
+		}
+
+		p.skip()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("gml: missing value for %s", key)
+		}
+
+		switch c := p.input[p.pos]; {
+		case c == '[':
+			p.pos++
+			l, err := p.list(true)
+			if err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, &gmlPair{key: key, value: l})
+		case c == '"':
+			p.pos++
+			start := p.pos
+			for p.pos < len(p.input) && p.input[p.pos] != '"' {
+				p.pos++
+			}
+			if p.pos >= len(p.input) {
+				return nil, fmt.Errorf("gml: unterminated string for %s", key)
+			}
+			s := string(p.input[start:p.pos])
+			p.pos++
+			pairs = append(pairs, &gmlPair{key: key, value: html.UnescapeString(s)})
+		default:
+			start := p.pos
+			for p.pos < len(p.input) && !unicode.IsSpace(p.input[p.pos]) && p.input[p.pos] != ']' {
+				p.pos++
+			}
+			pairs = append(pairs, &gmlPair{key: key, value: string(p.input[start:p.pos])})
+		}
+	}
+}
+
+func (p *gmlParser) key() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+// skip moves past white space and comment lines
+func (p *gmlParser) skip() {
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '#' {
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if !unicode.IsSpace(c) {
+			return
+		}
+		p.pos++
+	}
+}
@@ -22,19 +22,20 @@ var (
 	ErrEdgeNotFound        = errors.New("edge not found")
 	ErrEdgeAlreadyExists   = errors.New("edge already exists")
 	ErrEdgeVertsNotFound   = errors.New("edge vertices not found")
+	ErrInvalidGraph        = errors.New("invalid graph")
 )
 
 type Vertex struct {
 	Name       string            `yaml:"name" json:"name" binding:"required"`
 	Value      string            `yaml:"value" json:"value"`
-	Properties map[string]string `yaml:"properties" json:"properties"`
+	Properties map[string]string `yaml:"properties,omitempty" json:"properties"`
 	Weight     int               `yaml:"weight" json:"weight"`
 }
 
 type Edge struct {
 	Name       string            `yaml:"name" json:"name" binding:"required"`
 	Vertices   []*Vertex         `yaml:"vertices" json:"vertices" binding:"required"`
-	Properties map[string]string `yaml:"properties" json:"properties"`
+	Properties map[string]string `yaml:"properties,omitempty" json:"properties"`
 	Weight     int               `yaml:"weight" json:"weight"`
 }
 
@@ -42,6 +43,12 @@ type Graph struct {
 	Name     string    `yaml:"name" json:"name" binding:"required"`
 	Vertices []*Vertex `yaml:"vertices" json:"vertices"`
 	Edges    []*Edge   `yaml:"edges" json:"edges"`
+
+	// lookup indexes over Vertices and Edges, see index.go
+	vertexIndex map[string]*Vertex
+	edgeIndex   map[string][]*Edge
+	idIndex     map[string][]*Edge
+	adjIndex    map[string][]*Edge
 }
 
 func FromJson(bstring []byte) (*Graph, error) {
@@ -51,6 +58,11 @@ func FromJson(bstring []byte) (*Graph, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = G.checkDecoded()
+	if err != nil {
+		return nil, err
+	}
+	G.reindex()
 
 	return G, nil
 }
@@ -87,19 +99,15 @@ func (g *Graph) PrintGraph() {
 }
 
 func (g *Graph) FindEdge(e *Edge) ([]*Edge, bool) {
-	eList := make([]*Edge, 0)
-	for _, ge := range g.Edges {
-		m1 := make(map[string]string)
-		for _, v1 := range e.Vertices {
-			m1[v1.Name] = ""
-		}
-		m2 := make(map[string]string)
-		for _, v2 := range ge.Vertices {
-			m2[v2.Name] = ""
-		}
+	if len(e.Vertices) == 0 {
+		return nil, false
+	}
 
+	// any matching edge must be incident on the first vertex
+	eList := make([]*Edge, 0)
+	for _, ge := range g.IncidentEdges(e.Vertices[0].Name) {
 		// check if edge by name already exists
-		if reflect.DeepEqual(m1, m2) {
+		if sameVertices(e, ge) {
 			eList = append(eList, ge)
 		}
 	}
@@ -112,6 +120,26 @@ func (g *Graph) FindEdge(e *Edge) ([]*Edge, bool) {
 	return nil, false
 }
 
+// sameVertices checks if two edges join the same set of vertices
+func sameVertices(e1, e2 *Edge) bool {
+	if len(e1.Vertices) == 2 && len(e2.Vertices) == 2 {
+		a, b := e1.Vertices[0].Name, e1.Vertices[1].Name
+		x, y := e2.Vertices[0].Name, e2.Vertices[1].Name
+		return (a == x && b == y) || (a == y && b == x)
+	}
+
+	m1 := make(map[string]string)
+	for _, v1 := range e1.Vertices {
+		m1[v1.Name] = ""
+	}
+	m2 := make(map[string]string)
+	for _, v2 := range e2.Vertices {
+		m2[v2.Name] = ""
+	}
+
+	return reflect.DeepEqual(m1, m2)
+}
+
 func (g *Graph) AddEdge(v1, v2 *Vertex, prop map[string]string) (*Edge, error) {
 	e, err := NewEdge(v1, v2, prop)
 	if err != nil {
@@ -167,6 +195,7 @@ func (g *Graph) AddEdge(v1, v2 *Vertex, prop map[string]string) (*Edge, error) {
 	}
 
 	g.Edges = append(g.Edges, e)
+	g.indexEdge(e)
 
 	return e, nil
 }
@@ -187,15 +216,14 @@ func (g *Graph) AddEdgeObj(edge *Edge) error {
 }
 
 func (g *Graph) FindVertex(v *Vertex) (bool, *Vertex) {
-	for _, gv := range g.Vertices {
-		if gv.Name == v.Name {
-			// vertex found
-			return true, gv
-		}
+	gv, ok := g.GetVertex(v.Name)
+	if !ok {
+		// vertex not found
+		return false, nil
 	}
 
-	// vertex not found
-	return false, nil
+	// vertex found
+	return true, gv
 }
 
 func (g *Graph) AddVertex(name, value string, prop map[string]string) (*Vertex, error) {
@@ -205,16 +233,17 @@ func (g *Graph) AddVertex(name, value string, prop map[string]string) (*Vertex,
 		Properties: prop,
 	}
 
-	if g.Vertices != nil {
-		found, _ := g.FindVertex(v)
-		if found {
-			return nil, ErrVertexAlreadyExists
-		}
-	} else {
+	found, _ := g.FindVertex(v)
+	if found {
+		return nil, ErrVertexAlreadyExists
+	}
+
+	if g.Vertices == nil {
 		g.Vertices = make([]*Vertex, 0)
 	}
 
 	g.Vertices = append(g.Vertices, v)
+	g.indexVertex(v)
 
 	return v, nil
 }
@@ -352,6 +381,7 @@ func (g *Graph) DeepCopy() (*Graph, error) {
 	if err != nil {
 		return nil, err
 	}
+	ng.reindex()
 
 	return &ng, nil
 }
@@ -371,37 +401,156 @@ func (g *Graph) DeleteEdge(e *Edge) error {
 		return fmt.Errorf("delete edge called on graph without edges")
 	}
 
-	eList := make([]*Edge, 0)
-	for _, ge := range g.Edges {
-		//log.Debugf("edge: %v\n", ge)
-		if ge.Name != e.Name {
-			eList = append(eList, ge)
-		} else {
-			eprops := e.Properties
-			if eprops == nil {
-				return fmt.Errorf("edge needs properties for DeleteEdge")
-			}
-			geprops := ge.Properties
-			if geprops == nil {
-				log.Debugf("Missing props. Deleted edge: %v from graph\n", ge)
-				continue
-			}
+	remove := make(map[*Edge]bool)
+	for _, ge := range g.GetEdges(e.Name) {
+		eprops := e.Properties
+		if eprops == nil {
+			return fmt.Errorf("edge needs properties for DeleteEdge")
+		}
+		geprops := ge.Properties
+		if geprops == nil {
+			log.Debugf("Missing props. Deleted edge: %v from graph\n", ge)
+			remove[ge] = true
+			continue
+		}
 
-			if eprops["selector"] == geprops["selector"] {
-				log.Infof("Found Edge. Deleted edge: %v from graph\n", ge)
-				continue
-			} else {
-				eList = append(eList, ge)
+		if eprops["selector"] == geprops["selector"] {
+			log.Infof("Found Edge. Deleted edge: %v from graph\n", ge)
+			remove[ge] = true
+		}
+	}
+
+	g.removeEdges(remove)
+
+	return nil
+}
+
+// RemoveVertex removes a vertex and every edge connected to it, the removed
+// edges are returned.
+func (g *Graph) RemoveVertex(name string) ([]*Edge, error) {
+	if g == nil {
+		return nil, fmt.Errorf("remove vertex called on nil graph")
+	}
+
+	v, ok := g.GetVertex(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, name)
+	}
+
+	incident := g.IncidentEdges(name)
+	removed := make([]*Edge, 0, len(incident))
+	remove := make(map[*Edge]bool, len(incident))
+	for _, e := range incident {
+		if !remove[e] {
+			removed = append(removed, e)
+			remove[e] = true
+		}
+	}
+	g.removeEdges(remove)
+
+	vList := make([]*Vertex, 0, len(g.Vertices))
+	for _, gv := range g.Vertices {
+		if gv != v {
+			vList = append(vList, gv)
+		}
+	}
+	g.Vertices = vList
+	delete(g.vertexIndex, name)
+	delete(g.adjIndex, name)
+
+	log.Infof("Removed vertex %s and %d edges from graph\n", name, len(removed))
+
+	return removed, nil
+}
+
+// RemoveEdge removes the edges with the given uuid, or name for edges that
+// were added without a uuid.
+func (g *Graph) RemoveEdge(uuid string) error {
+	if g == nil {
+		return fmt.Errorf("remove edge called on nil graph")
+	}
+
+	eList := g.GetEdgesByID(uuid)
+	if len(eList) == 0 {
+		return fmt.Errorf("%w: %s", ErrEdgeNotFound, uuid)
+	}
+
+	remove := make(map[*Edge]bool, len(eList))
+	for _, e := range eList {
+		remove[e] = true
+	}
+	g.removeEdges(remove)
+
+	log.Infof("Removed edge %s from graph\n", uuid)
+
+	return nil
+}
+
+// UpdateVertexProperties sets and then unsets properties on a vertex.  Values
+// of known attributes are converted to the unit of the attribute.  Edges
+// decoded from json or yaml hold copies of their vertices, which are given
+// the same properties.
+func (g *Graph) UpdateVertexProperties(name string, set map[string]string, unset []string) error {
+	v, ok := g.GetVertex(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrVertexNotFound, name)
+	}
+
+	set, err := NormalizeProperties(set)
+	if err != nil {
+		return err
+	}
+	v.Properties = updateProperties(v.Properties, set, unset)
+
+	for _, e := range g.IncidentEdges(name) {
+		for _, ev := range e.Vertices {
+			if ev.Name == name {
+				ev.Properties = v.Properties
 			}
 		}
 	}
 
-	g.Edges = eList
+	return nil
+}
+
+// UpdateEdgeProperties sets and then unsets properties on the edges with the
+// given uuid.  Changing the uuid property re-keys the edge.
+func (g *Graph) UpdateEdgeProperties(uuid string, set map[string]string, unset []string) error {
+	eList := g.GetEdgesByID(uuid)
+	if len(eList) == 0 {
+		return fmt.Errorf("%w: %s", ErrEdgeNotFound, uuid)
+	}
+
+	set, err := NormalizeProperties(set)
+	if err != nil {
+		return err
+	}
+
+	// copy, the index entry changes underneath us if the uuid changes
+	for _, e := range append([]*Edge{}, eList...) {
+		g.unindexID(e.ID(), e)
+		e.Properties = updateProperties(e.Properties, set, unset)
+		g.idIndex[e.ID()] = append(g.idIndex[e.ID()], e)
+	}
 
 	return nil
 }
 
-// TODO: this is a function only for pruning edges based on a selector
+func updateProperties(prop map[string]string, set map[string]string, unset []string) map[string]string {
+	if prop == nil {
+		prop = make(map[string]string, len(set))
+	}
+	for k, v := range set {
+		prop[k] = v
+	}
+	for _, k := range unset {
+		delete(prop, k)
+	}
+	return prop
+}
+
+// PruneGraph keeps the edges whose selector is sel, see Subgraph for
+// general filters.
 func PruneGraph(g *Graph, sel string) (*Graph, error) {
 	if sel == "" {
 		log.Warnf("Prune called without selector value")
@@ -419,52 +568,5 @@ func PruneGraph(g *Graph, sel string) (*Graph, error) {
 
 	log.Infof("prune called with selector: %s\n", sel)
 
-	newGraph, err := g.DeepCopy()
-	if err != nil {
-		return nil, err
-	}
-
-	eList := make([]*Edge, len(newGraph.Edges))
-	copy(eList, newGraph.Edges)
-
-	for _, e := range eList {
-		if e.Properties != nil {
-			//log.Infof("Prune, edge: %s [%s] ? %s", e.Name, e.Properties["selector"], sel)
-			val, ok := e.Properties["selector"]
-			//log.Infof("val: %s ok: [%v]", val, ok)
-			if ok {
-				if val != sel {
-					//log.Infof("Deleting Edge- not selected: %v\n", e)
-
-					//log.Infof("before delete edge")
-					//newGraph.PrintGraph()
-
-					err = newGraph.DeleteEdge(e)
-					if err != nil {
-						return nil, err
-					}
-
-					//log.Infof("adter delete edge")
-					//newGraph.PrintGraph()
-
-				} else {
-					log.Infof("Edge selected: %v\n", e)
-				}
-			} else {
-				log.Infof("Deleting Edge- no selector: %v\n", e)
-				err = newGraph.DeleteEdge(e)
-				if err != nil {
-					return nil, err
-				}
-			}
-		} else {
-			log.Infof("Deleting Edge- no properties to select: %v\n", e)
-			err = newGraph.DeleteEdge(e)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	return newGraph, nil
+	return g.Subgraph(nil, SelectorFilter(sel))
 }
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"reflect"
 	"testing"
@@ -366,3 +367,112 @@ func TestLoadingGraph(t *testing.T) {
 			assert.Equal(t, valid, jsonG, "json should be equal")
 	*/
 }
+
+func TestGraphRemoveVertex(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	removed, err := G.RemoveVertex("b")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 4, len(removed), "every edge on b is removed")
+
+	found, _ := G.FindVertex(&Vertex{Name: "b"})
+	assert.False(t, found, "b has been removed")
+	assert.Equal(t, 3, len(G.Vertices))
+	assert.Equal(t, 2, len(G.Edges))
+	assert.Equal(t, 1, G.Degree("a"))
+	assert.Equal(t, 0, len(G.GetEdges("a-b")))
+
+	_, err = G.RemoveVertex("b")
+	assert.True(t, errors.Is(err, ErrVertexNotFound), "b is already gone")
+
+	GG, err := G.DeepCopy()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, reflect.DeepEqual(G, GG), "indexes match a rebuilt graph")
+}
+
+func TestGraphRemoveEdge(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	err := G.RemoveEdge("ab2")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 5, len(G.Edges))
+	assert.Equal(t, 0, len(G.GetEdgesByID("ab2")))
+	assert.Equal(t, 1, len(G.GetEdges("a-b")), "the parallel edge remains")
+
+	err = G.RemoveEdge("ab2")
+	assert.True(t, errors.Is(err, ErrEdgeNotFound), "ab2 is already gone")
+
+	_, err = G.AddEdge(&Vertex{Name: "c"}, &Vertex{Name: "e"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = G.RemoveEdge("c-e")
+	assert.Nil(t, err, "edges without a uuid are removed by name")
+
+	GG, err := G.DeepCopy()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, reflect.DeepEqual(G, GG), "indexes match a rebuilt graph")
+}
+
+func TestGraphUpdateProperties(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	err := G.UpdateVertexProperties("a", map[string]string{"cpu": "4"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, v := G.FindVertex(&Vertex{Name: "a"})
+	assert.Equal(t, "4", v.Properties["cpu"])
+
+	err = G.UpdateVertexProperties("a", nil, []string{"cpu"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, ok := v.Properties["cpu"]
+	assert.False(t, ok, "cpu was unset")
+
+	// the vertex copies of decoded edges follow the vertex
+	out, err := G.ToJson()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	J, err := FromJson([]byte(out))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = J.UpdateVertexProperties("a", map[string]string{"cpu": "2"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	for _, e := range J.IncidentEdges("a") {
+		for _, ev := range e.Vertices {
+			if ev.Name == "a" {
+				assert.Equal(t, "2", ev.Properties["cpu"], "edge %s", e.Name)
+			}
+		}
+	}
+
+	err = G.UpdateVertexProperties("e", nil, nil)
+	assert.True(t, errors.Is(err, ErrVertexNotFound))
+
+	err = G.UpdateEdgeProperties("ac", map[string]string{"bw": "10", "uuid": "ac2"}, []string{"lat"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 0, len(G.GetEdgesByID("ac")), "uuid changed")
+
+	eList := G.GetEdgesByID("ac2")
+	assert.Equal(t, 1, len(eList))
+	assert.Equal(t, map[string]string{"uuid": "ac2", "bw": "10", "selector": "x"}, eList[0].Properties)
+
+	err = G.UpdateEdgeProperties("ac", nil, nil)
+	assert.True(t, errors.Is(err, ErrEdgeNotFound))
+}
@@ -0,0 +1,243 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// GraphML keys for the Vertex and Edge fields, every other key is a property.
+const (
+	graphmlValue  = "value"
+	graphmlWeight = "weight"
+	graphmlName   = "name"
+)
+
+type graphmlDoc struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr,omitempty"`
+	Keys    []graphmlKey   `xml:"key"`
+	Graphs  []graphmlGraph `xml:"graph"`
+}
+
+type graphmlKey struct {
+	ID      string `xml:"id,attr"`
+	For     string `xml:"for,attr"`
+	Name    string `xml:"attr.name,attr,omitempty"`
+	Type    string `xml:"attr.type,attr,omitempty"`
+	Default string `xml:"default,omitempty"`
+}
+
+type graphmlGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphmlNode `xml:"node"`
+	Edges       []graphmlEdge `xml:"edge"`
+}
+
+type graphmlNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphmlData `xml:"data"`
+}
+
+type graphmlEdge struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphmlData `xml:"data"`
+}
+
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ToGraphML writes the graph as an undirected GraphML document.  Vertex
+// and edge properties are string keys, value, weight and the edge name are
+// kept in keys of their own so the graph can be read back unchanged.
+func (g *Graph) ToGraphML() (string, error) {
+	// every property name in use needs a key
+	vprops := make(map[string]string)
+	eprops := make(map[string]string)
+	for _, v := range g.Vertices {
+		for k := range v.Properties {
+			vprops[k] = ""
+		}
+	}
+	for _, e := range g.Edges {
+		for k := range e.Properties {
+			eprops[k] = ""
+		}
+	}
+
+	doc := &graphmlDoc{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphmlKey{
+			{ID: graphmlValue, For: "node", Name: graphmlValue, Type: "string"},
+			{ID: graphmlWeight, For: "all", Name: graphmlWeight, Type: "int"},
+			{ID: graphmlName, For: "edge", Name: graphmlName, Type: "string"},
+		},
+	}
+
+	vkeys := make(map[string]string)
+	for i, k := range sortedProps(vprops) {
+		vkeys[k] = fmt.Sprintf("v%d", i)
+		doc.Keys = append(doc.Keys, graphmlKey{ID: vkeys[k], For: "node", Name: k, Type: "string"})
+	}
+	ekeys := make(map[string]string)
+	for i, k := range sortedProps(eprops) {
+		ekeys[k] = fmt.Sprintf("e%d", i)
+		doc.Keys = append(doc.Keys, graphmlKey{ID: ekeys[k], For: "edge", Name: k, Type: "string"})
+	}
+
+	gml := graphmlGraph{ID: g.Name, EdgeDefault: "undirected"}
+
+	for _, v := range g.Vertices {
+		n := graphmlNode{ID: v.Name}
+		if v.Value != "" {
+			n.Data = append(n.Data, graphmlData{Key: graphmlValue, Value: v.Value})
+		}
+		if v.Weight != 0 {
+			n.Data = append(n.Data, graphmlData{Key: graphmlWeight, Value: strconv.Itoa(v.Weight)})
+		}
+		for _, k := range sortedProps(v.Properties) {
+			n.Data = append(n.Data, graphmlData{Key: vkeys[k], Value: v.Properties[k]})
+		}
+		gml.Nodes = append(gml.Nodes, n)
+	}
+
+	for _, e := range g.Edges {
+		if len(e.Vertices) != 2 {
+			return "", fmt.Errorf("edge %s does not have two vertices", e.Name)
+		}
+		ge := graphmlEdge{Source: e.Vertices[0].Name, Target: e.Vertices[1].Name}
+		ge.Data = append(ge.Data, graphmlData{Key: graphmlName, Value: e.Name})
+		if e.Weight != 0 {
+			ge.Data = append(ge.Data, graphmlData{Key: graphmlWeight, Value: strconv.Itoa(e.Weight)})
+		}
+		for _, k := range sortedProps(e.Properties) {
+			ge.Data = append(ge.Data, graphmlData{Key: ekeys[k], Value: e.Properties[k]})
+		}
+		gml.Edges = append(gml.Edges, ge)
+	}
+
+	doc.Graphs = []graphmlGraph{gml}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(out) + "\n", nil
+}
+
+// FromGraphML reads the first graph of a GraphML document.  Keys written
+// by other tools become properties named by their attr.name, and edges
+// without a name are named like NewEdge names them.
+func FromGraphML(data []byte) (*Graph, error) {
+	doc := &graphmlDoc{}
+	err := xml.Unmarshal(data, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Graphs) == 0 {
+		return nil, fmt.Errorf("graphml: no graph found")
+	}
+	gml := doc.Graphs[0]
+
+	keys := make(map[string]graphmlKey)
+	for _, k := range doc.Keys {
+		if k.Name == "" {
+			k.Name = k.ID
+		}
+		keys[k.ID] = k
+	}
+
+	// defaults apply to every element the key is for
+	defaults := func(kind string) map[string]string {
+		var props map[string]string
+		for _, k := range doc.Keys {
+			if k.Default == "" || (k.For != kind && k.For != "all") {
+				continue
+			}
+			if k.ID == graphmlValue || k.ID == graphmlWeight || k.ID == graphmlName {
+				continue
+			}
+			props = setProperty(props, keys[k.ID].Name, k.Default)
+		}
+		return props
+	}
+
+	G := &Graph{Name: gml.ID, Vertices: make([]*Vertex, 0), Edges: make([]*Edge, 0)}
+	G.reindex()
+
+	for _, n := range gml.Nodes {
+		v := &Vertex{Name: n.ID, Properties: defaults("node")}
+		for _, d := range n.Data {
+			switch d.Key {
+			case graphmlValue:
+				v.Value = d.Value
+			case graphmlWeight:
+				v.Weight, err = parseWeight(d.Value)
+				if err != nil {
+					return nil, fmt.Errorf("graphml: node %s weight: %v", n.ID, err)
+				}
+			default:
+				v.Properties = setProperty(v.Properties, graphmlPropName(keys, d.Key), d.Value)
+			}
+		}
+
+		err = G.appendVertex(v)
+		if err != nil {
+			return nil, fmt.Errorf("graphml: node %s: %w", n.ID, err)
+		}
+	}
+
+	for _, ge := range gml.Edges {
+		verts, err := G.edgeEndpoints(ge.Source, ge.Target)
+		if err != nil {
+			return nil, fmt.Errorf("graphml: %w", err)
+		}
+
+		e := &Edge{
+			Name:       fmt.Sprintf("%s-%s", ge.Source, ge.Target),
+			Vertices:   verts,
+			Properties: defaults("edge"),
+		}
+		for _, d := range ge.Data {
+			switch d.Key {
+			case graphmlName:
+				e.Name = d.Value
+			case graphmlWeight:
+				e.Weight, err = parseWeight(d.Value)
+				if err != nil {
+					return nil, fmt.Errorf("graphml: edge %s weight: %v", e.Name, err)
+				}
+			default:
+				e.Properties = setProperty(e.Properties, graphmlPropName(keys, d.Key), d.Value)
+			}
+		}
+
+		G.appendEdge(e)
+	}
+
+	return G, nil
+}
+
+func graphmlPropName(keys map[string]graphmlKey, id string) string {
+	if k, ok := keys[id]; ok {
+		return k.Name
+	}
+	return id
+}
+
+func sortedProps(props map[string]string) []string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package graph
+
+import "fmt"
+
+// The Vertices and Edges slices are the serialized form of the graph and
+// keep insertion order.  Lookups go through the indexes below, which are
+// kept up to date by the graph functions and built on first use.  Code that
+// changes the slices behind the graph's back must call Invalidate after.
+
+// Invalidate drops the indexes, they are built again on the next lookup.
+// It is needed after Vertices or Edges are assigned to directly.
+func (g *Graph) Invalidate() {
+	g.vertexIndex = nil
+}
+
+// ensureIndex builds the indexes if they are missing.
+func (g *Graph) ensureIndex() {
+	if g.vertexIndex == nil {
+		g.reindex()
+	}
+}
+
+// checkDecoded rejects a decoded graph the indexes can not be built from:
+// empty vertices or edges, edges with an empty end, and vertices with an
+// empty or repeated name.
+func (g *Graph) checkDecoded() error {
+	names := make(map[string]bool, len(g.Vertices))
+	for i, v := range g.Vertices {
+		if v == nil {
+			return fmt.Errorf("%w: vertex %d is empty", ErrInvalidGraph, i)
+		}
+		if v.Name == "" {
+			return fmt.Errorf("%w: vertex %d has no name", ErrInvalidGraph, i)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("%w: vertex %s is there twice", ErrInvalidGraph, v.Name)
+		}
+		names[v.Name] = true
+	}
+	for i, e := range g.Edges {
+		if e == nil {
+			return fmt.Errorf("%w: edge %d is empty", ErrInvalidGraph, i)
+		}
+		for j, v := range e.Vertices {
+			if v == nil {
+				return fmt.Errorf("%w: edge %d vertex %d is empty", ErrInvalidGraph, i, j)
+			}
+			if v.Name == "" {
+				return fmt.Errorf("%w: edge %d vertex %d has no name", ErrInvalidGraph, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// reindex builds the vertex, edge and adjacency indexes from scratch.
+func (g *Graph) reindex() {
+	g.vertexIndex = make(map[string]*Vertex, len(g.Vertices))
+	g.edgeIndex = make(map[string][]*Edge, len(g.Edges))
+	g.idIndex = make(map[string][]*Edge, len(g.Edges))
+	g.adjIndex = make(map[string][]*Edge, len(g.Vertices))
+
+	for _, v := range g.Vertices {
+		g.indexVertex(v)
+	}
+	for _, e := range g.Edges {
+		g.indexEdge(e)
+	}
+}
+
+func (g *Graph) indexVertex(v *Vertex) {
+	if _, ok := g.vertexIndex[v.Name]; ok {
+		return
+	}
+	g.vertexIndex[v.Name] = v
+	if _, ok := g.adjIndex[v.Name]; !ok {
+		g.adjIndex[v.Name] = make([]*Edge, 0)
+	}
+}
+
+func (g *Graph) indexEdge(e *Edge) {
+	g.edgeIndex[e.Name] = append(g.edgeIndex[e.Name], e)
+	g.idIndex[e.ID()] = append(g.idIndex[e.ID()], e)
+	for _, v := range e.Vertices {
+		g.adjIndex[v.Name] = append(g.adjIndex[v.Name], e)
+	}
+}
+
+// removeEdges drops a set of edges from the graph and the indexes.
+func (g *Graph) removeEdges(remove map[*Edge]bool) {
+	if len(remove) == 0 {
+		return
+	}
+	g.ensureIndex()
+
+	eList := make([]*Edge, 0, len(g.Edges))
+	for _, e := range g.Edges {
+		if !remove[e] {
+			eList = append(eList, e)
+			continue
+		}
+
+		g.edgeIndex[e.Name] = withoutEdge(g.edgeIndex[e.Name], e)
+		if len(g.edgeIndex[e.Name]) == 0 {
+			delete(g.edgeIndex, e.Name)
+		}
+		g.unindexID(e.ID(), e)
+		for _, v := range e.Vertices {
+			if adj, ok := g.adjIndex[v.Name]; ok {
+				g.adjIndex[v.Name] = withoutEdge(adj, e)
+			}
+		}
+	}
+
+	g.Edges = eList
+}
+
+func (g *Graph) unindexID(id string, e *Edge) {
+	g.idIndex[id] = withoutEdge(g.idIndex[id], e)
+	if len(g.idIndex[id]) == 0 {
+		delete(g.idIndex, id)
+	}
+}
+
+func withoutEdge(edges []*Edge, e *Edge) []*Edge {
+	out := make([]*Edge, 0, len(edges))
+	for _, x := range edges {
+		if x != e {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// GetVertex returns the vertex with the given name
+func (g *Graph) GetVertex(name string) (*Vertex, bool) {
+	g.ensureIndex()
+	v, ok := g.vertexIndex[name]
+	return v, ok
+}
+
+// GetEdges returns the edges with the given name, parallel edges between the
+// same vertices share a name.
+func (g *Graph) GetEdges(name string) []*Edge {
+	g.ensureIndex()
+	return g.edgeIndex[name]
+}
+
+// GetEdgesByID returns the edges with the given id, the uuid property of the
+// edge or its name if it has no uuid.
+func (g *Graph) GetEdgesByID(id string) []*Edge {
+	g.ensureIndex()
+	return g.idIndex[id]
+}
+
+// IncidentEdges returns the edges connected to a vertex in the order they
+// were added.
+func (g *Graph) IncidentEdges(name string) []*Edge {
+	g.ensureIndex()
+	return g.adjIndex[name]
+}
+
+// Degree returns the number of edges connected to a vertex
+func (g *Graph) Degree(name string) int {
+	return len(g.IncidentEdges(name))
+}
@@ -0,0 +1,175 @@
+package graph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphIndex(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	v, ok := G.GetVertex("b")
+	assert.True(t, ok, "b should be indexed")
+	assert.Equal(t, "b", v.Name)
+
+	_, ok = G.GetVertex("e")
+	assert.False(t, ok, "e is not in the graph")
+
+	assert.Equal(t, 2, len(G.GetEdges("a-b")), "parallel edges share a name")
+	assert.Equal(t, 4, G.Degree("b"), "b has two parallel edges on each side")
+	assert.Equal(t, 0, G.Degree("e"), "e has no edges")
+
+	eList, found := G.FindEdge(&Edge{Vertices: []*Vertex{{Name: "d"}, {Name: "b"}}})
+	assert.True(t, found, "edges are undirected")
+	assert.Equal(t, 2, len(eList))
+
+	err := G.DeleteEdge(&Edge{Name: "a-b", Properties: map[string]string{"selector": "y"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 1, len(G.GetEdges("a-b")), "edge with selector y removed")
+	assert.Equal(t, 3, G.Degree("b"))
+	assert.Equal(t, 5, len(G.Edges))
+
+	// assigning the slices directly needs an Invalidate before the next use
+	G.Vertices = append(G.Vertices, &Vertex{Name: "e"})
+	G.Edges = append(G.Edges, &Edge{Name: "d-e", Vertices: []*Vertex{{Name: "d"}, {Name: "e"}}})
+	G.Invalidate()
+
+	_, ok = G.GetVertex("e")
+	assert.True(t, ok, "index rebuilt after direct assignment")
+	assert.Equal(t, 1, G.Degree("e"))
+
+	// replacing a vertex keeps the lengths the same
+	for i, v := range G.Vertices {
+		if v.Name == "e" {
+			G.Vertices[i] = &Vertex{Name: "f"}
+		}
+	}
+	G.Invalidate()
+
+	_, ok = G.GetVertex("e")
+	assert.False(t, ok, "e was replaced")
+	_, ok = G.GetVertex("f")
+	assert.True(t, ok, "f replaced e")
+}
+
+func TestGraphIndexJson(t *testing.T) {
+	contents, err := ioutil.ReadFile("test_graph_1.json")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	G, err := FromJson(contents)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	out, err := G.ToJson()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	GG, err := FromJson([]byte(out))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, reflect.DeepEqual(G, GG), "json round trip keeps the graph")
+
+	for _, v := range G.Vertices {
+		found, gv := G.FindVertex(v)
+		assert.True(t, found, "vertex %s indexed from json", v.Name)
+		assert.Equal(t, v, gv)
+	}
+	for _, e := range G.Edges {
+		_, found := G.FindEdge(e)
+		assert.True(t, found, "edge %s indexed from json", e.Name)
+	}
+}
+
+// benchGraph builds a ring of n vertices with a chord every 10 vertices and
+// two parallel networks on every link, similar to a large inventory.
+func benchGraph(b *testing.B, n int) *Graph {
+	G := &Graph{Name: "bench"}
+
+	for i := 0; i < n; i++ {
+		_, err := G.AddVertex(fmt.Sprintf("v%d", i), "", map[string]string{"cpu": "8"})
+		if err != nil {
+			b.Fatalf("%v", err)
+		}
+	}
+
+	link := func(i, j, k int) {
+		v1 := &Vertex{Name: fmt.Sprintf("v%d", i)}
+		v2 := &Vertex{Name: fmt.Sprintf("v%d", j)}
+		for net := 0; net < 2; net++ {
+			_, err := G.AddEdge(v1, v2, map[string]string{
+				"uuid":     fmt.Sprintf("l%d-%d", k, net),
+				"lat":      fmt.Sprintf("%d", 1+(i+j)%7),
+				"bw":       "1000",
+				"selector": fmt.Sprintf("net%d", net),
+			})
+			if err != nil {
+				b.Fatalf("%v", err)
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		link(i, (i+1)%n, i)
+		if i%10 == 0 {
+			link(i, (i+n/2)%n, n+i)
+		}
+	}
+
+	return G
+}
+
+func benchmarkBuild(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		benchGraph(b, n)
+	}
+}
+
+func BenchmarkBuildGraph1000(b *testing.B)  { benchmarkBuild(b, 1000) }
+func BenchmarkBuildGraph10000(b *testing.B) { benchmarkBuild(b, 10000) }
+func BenchmarkBuildGraph50000(b *testing.B) { benchmarkBuild(b, 50000) }
+
+func benchmarkLookup(b *testing.B, n int) {
+	G := benchGraph(b, n)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		j := i % n
+		G.FindVertex(&Vertex{Name: fmt.Sprintf("v%d", j)})
+		G.FindEdge(&Edge{Vertices: []*Vertex{
+			{Name: fmt.Sprintf("v%d", j)},
+			{Name: fmt.Sprintf("v%d", (j+1)%n)},
+		}})
+	}
+}
+
+func BenchmarkLookup1000(b *testing.B)  { benchmarkLookup(b, 1000) }
+func BenchmarkLookup10000(b *testing.B) { benchmarkLookup(b, 10000) }
+func BenchmarkLookup50000(b *testing.B) { benchmarkLookup(b, 50000) }
+
+func benchmarkShortestPath(b *testing.B, n int) {
+	G := benchGraph(b, n)
+	opts := &PathOptions{Weight: PropertyWeight("lat")}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := G.ShortestPath("v0", fmt.Sprintf("v%d", n/3), opts)
+		if err != nil {
+			b.Fatalf("%v", err)
+		}
+	}
+}
+
+func BenchmarkShortestPath1000(b *testing.B)  { benchmarkShortestPath(b, 1000) }
+func BenchmarkShortestPath10000(b *testing.B) { benchmarkShortestPath(b, 10000) }
+func BenchmarkShortestPath50000(b *testing.B) { benchmarkShortestPath(b, 50000) }
@@ -0,0 +1,332 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// KShortestPaths returns up to k loopless paths from src to dst in order of
+// increasing cost using Yen's algorithm.  Parallel edges between the same
+// vertices are distinct, so two paths may visit the same vertices over
+// different edges.
+func (g *Graph) KShortestPaths(src, dst string, k int, opts *PathOptions) ([]*Path, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("k must be at least 1: %d", k)
+	}
+
+	base := newSearchSpec(opts)
+
+	first, err := g.search(src, dst, base)
+	if err != nil {
+		return nil, err
+	}
+
+	A := []*Path{first}
+	B := make([]*Path, 0)
+
+	for len(A) < k {
+		last := A[len(A)-1]
+
+		for i := 0; i < len(last.Edges); i++ {
+			spur := last.Vertices[i]
+			rootV := last.Vertices[:i+1]
+			rootE := last.Edges[:i]
+
+			spec := base.clone()
+			if base.maxHops > 0 {
+				if base.maxHops-i <= 0 {
+					continue
+				}
+				spec.maxHops = base.maxHops - i
+			}
+
+			// remove the next edge of every known path sharing this root
+			for _, p := range A {
+				if p.hasRoot(rootV, rootE) {
+					spec.exEdges[p.Edges[i]] = true
+				}
+			}
+			for _, v := range rootV[:i] {
+				spec.exVerts[v.Name] = true
+			}
+
+			spurPath, err := g.search(spur.Name, dst, spec)
+			if err != nil {
+				if errors.Is(err, ErrNoPath) {
+					continue
+				}
+				return nil, err
+			}
+
+			rootCost := 0.0
+			for _, e := range rootE {
+				w, err := base.cost(e)
+				if err != nil {
+					return nil, err
+				}
+				rootCost += w
+			}
+
+			cand := &Path{
+				Vertices: append(append([]*Vertex{}, rootV[:i]...), spurPath.Vertices...),
+				Edges:    append(append([]*Edge{}, rootE...), spurPath.Edges...),
+				Cost:     rootCost + spurPath.Cost,
+			}
+
+			if !containsPath(A, cand) && !containsPath(B, cand) {
+				B = append(B, cand)
+			}
+		}
+
+		if len(B) == 0 {
+			break
+		}
+
+		sortPaths(B)
+		A = append(A, B[0])
+		B = B[1:]
+	}
+
+	return A, nil
+}
+
+// EdgeDisjointPaths returns a maximum set of paths from src to dst that do
+// not share an edge, chosen with the least total cost.  Paths longer than
+// opts.MaxHops are dropped from the result.
+func (g *Graph) EdgeDisjointPaths(src, dst string, opts *PathOptions) ([]*Path, error) {
+	return g.disjointPaths(src, dst, opts, false)
+}
+
+// VertexDisjointPaths returns a maximum set of paths from src to dst that
+// share no vertex other than src and dst, chosen with the least total cost.
+// Paths longer than opts.MaxHops are dropped from the result.
+func (g *Graph) VertexDisjointPaths(src, dst string, opts *PathOptions) ([]*Path, error) {
+	return g.disjointPaths(src, dst, opts, true)
+}
+
+// disjointPaths runs a unit capacity min cost flow.  Each undirected edge
+// becomes a pair of arcs, and for vertex disjoint paths each vertex is split
+// into an in and out node joined by a unit capacity arc.
+func (g *Graph) disjointPaths(src, dst string, opts *PathOptions, vertexDisjoint bool) ([]*Path, error) {
+	spec := newSearchSpec(opts)
+
+	index := make(map[string]int, len(g.Vertices))
+	verts := make(map[string]*Vertex, len(g.Vertices))
+	for _, v := range g.Vertices {
+		if spec.exVerts[v.Name] {
+			continue
+		}
+		index[v.Name] = len(index)
+		verts[v.Name] = v
+	}
+
+	if _, ok := index[src]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, src)
+	}
+	if _, ok := index[dst]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, dst)
+	}
+	if src == dst {
+		return nil, fmt.Errorf("source and target are the same vertex: %s", src)
+	}
+
+	in := func(name string) int {
+		if vertexDisjoint {
+			return 2 * index[name]
+		}
+		return index[name]
+	}
+	out := func(name string) int {
+		if vertexDisjoint {
+			return 2*index[name] + 1
+		}
+		return index[name]
+	}
+
+	nodes := len(index)
+	if vertexDisjoint {
+		nodes *= 2
+	}
+	net := newFlowNetwork(nodes)
+
+	if vertexDisjoint {
+		for _, v := range g.Vertices {
+			if _, ok := index[v.Name]; !ok {
+				continue
+			}
+			capacity := 1.0
+			if v.Name == src || v.Name == dst {
+				capacity = math.Inf(1)
+			}
+			net.addArc(in(v.Name), out(v.Name), capacity, 0, nil)
+		}
+	}
+
+	for _, e := range g.Edges {
+		if len(e.Vertices) != 2 || !spec.edgeAllowed(e) {
+			continue
+		}
+		u, v := e.Vertices[0].Name, e.Vertices[1].Name
+		if _, ok := index[u]; !ok {
+			continue
+		}
+		if _, ok := index[v]; !ok {
+			continue
+		}
+
+		w, err := spec.cost(e)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsInf(w, 1) {
+			continue
+		}
+
+		net.addArc(out(u), in(v), 1, w, e)
+		net.addArc(out(v), in(u), 1, w, e)
+	}
+
+	net.minCostFlow(out(src), in(dst), math.Inf(1))
+
+	// collect the edges that carry flow, an edge used in both directions
+	// cancels out
+	type hop struct {
+		edge *Edge
+		to   string
+	}
+	used := make(map[string][]*hop)
+	dir := make(map[*Edge]string)
+	for i := 0; i < len(net.arcs); i += 2 {
+		a := net.arcs[i]
+		if a.edge == nil || a.flow < 1-flowEpsilon {
+			continue
+		}
+		from := a.edge.Vertices[0].Name
+		if a.from != out(from) {
+			from = a.edge.Vertices[1].Name
+		}
+		if prev, ok := dir[a.edge]; ok && prev != from {
+			delete(dir, a.edge)
+			continue
+		}
+		dir[a.edge] = from
+	}
+	for _, e := range g.Edges {
+		from, ok := dir[e]
+		if !ok {
+			continue
+		}
+		used[from] = append(used[from], &hop{edge: e, to: e.Other(from)})
+	}
+
+	paths := make([]*Path, 0)
+	for len(used[src]) > 0 {
+		p := &Path{Vertices: []*Vertex{verts[src]}, Edges: []*Edge{}}
+		seen := map[string]int{src: 0}
+
+		cur := src
+		for cur != dst {
+			hops := used[cur]
+			if len(hops) == 0 {
+				break
+			}
+			h := hops[0]
+			used[cur] = hops[1:]
+
+			// cut any cycle the flow took through a zero cost loop
+			if at, ok := seen[h.to]; ok {
+				for _, v := range p.Vertices[at+1:] {
+					delete(seen, v.Name)
+				}
+				p.Vertices = p.Vertices[:at+1]
+				p.Edges = p.Edges[:at]
+				cur = h.to
+				continue
+			}
+
+			seen[h.to] = len(p.Vertices)
+			p.Vertices = append(p.Vertices, verts[h.to])
+			p.Edges = append(p.Edges, h.edge)
+			cur = h.to
+		}
+
+		if cur != dst {
+			break
+		}
+
+		for _, e := range p.Edges {
+			w, err := spec.cost(e)
+			if err != nil {
+				return nil, err
+			}
+			p.Cost += w
+		}
+
+		if spec.maxHops > 0 && p.Hops() > spec.maxHops {
+			continue
+		}
+
+		paths = append(paths, p)
+	}
+
+	sortPaths(paths)
+
+	return paths, nil
+}
+
+// hasRoot checks if the path starts with the given vertices and edges.
+func (p *Path) hasRoot(rootV []*Vertex, rootE []*Edge) bool {
+	if len(p.Vertices) < len(rootV) || len(p.Edges) <= len(rootE) {
+		return false
+	}
+	for i, v := range rootV {
+		if p.Vertices[i].Name != v.Name {
+			return false
+		}
+	}
+	for i, e := range rootE {
+		if p.Edges[i] != e {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal compares paths by their vertices and the exact edges used.
+func (p *Path) Equal(o *Path) bool {
+	if len(p.Edges) != len(o.Edges) || len(p.Vertices) != len(o.Vertices) {
+		return false
+	}
+	for i := range p.Vertices {
+		if p.Vertices[i].Name != o.Vertices[i].Name {
+			return false
+		}
+	}
+	for i := range p.Edges {
+		if p.Edges[i] != o.Edges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPath(paths []*Path, p *Path) bool {
+	for _, q := range paths {
+		if q.Equal(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortPaths orders paths by cost, then by hops
+func sortPaths(paths []*Path) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		if paths[i].Cost == paths[j].Cost {
+			return paths[i].Hops() < paths[j].Hops()
+		}
+		return paths[i].Cost < paths[j].Cost
+	})
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func edgeIDs(p *Path) []string {
+	ids := make([]string, 0)
+	for _, e := range p.Edges {
+		ids = append(ids, e.ID())
+	}
+	return ids
+}
+
+func TestKShortestPaths(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	paths, err := G.KShortestPaths("a", "d", 10, &PathOptions{Weight: PropertyWeight("lat")})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, 5, len(paths), "every combination of parallel edges is a path")
+
+	costs := make([]float64, 0)
+	for _, p := range paths {
+		costs = append(costs, p.Cost)
+	}
+	assert.Equal(t, []float64{2, 3, 4, 4, 5}, costs, "paths in order of cost")
+	assert.Equal(t, []string{"ab1", "bd1"}, edgeIDs(paths[0]))
+	assert.Equal(t, []string{"ab2", "bd1"}, edgeIDs(paths[1]))
+	assert.Equal(t, []string{"ab2", "bd2"}, edgeIDs(paths[4]))
+
+	paths, err = G.KShortestPaths("a", "d", 2, &PathOptions{Weight: PropertyWeight("lat")})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, len(paths), "only k paths are returned")
+
+	paths, err = G.KShortestPaths("a", "d", 10, &PathOptions{ExcludeEdges: []string{"ab2", "bd2"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, len(paths), "exclusions apply to every path")
+
+	_, err = G.KShortestPaths("a", "d", 0, nil)
+	assert.NotNil(t, err, "k must be positive")
+}
+
+func TestDisjointPaths(t *testing.T) {
+	G := testGraph(t, "test-multi")
+	lat := &PathOptions{Weight: PropertyWeight("lat")}
+
+	paths, err := G.EdgeDisjointPaths("a", "d", lat)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 3, len(paths), "two parallel paths through b and one through c")
+
+	usedEdges := make(map[string]bool)
+	for _, p := range paths {
+		for _, id := range edgeIDs(p) {
+			assert.False(t, usedEdges[id], "edge %s used twice", id)
+			usedEdges[id] = true
+		}
+	}
+	assert.Equal(t, 6, len(usedEdges), "every edge is used once")
+
+	paths, err = G.VertexDisjointPaths("a", "d", lat)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, len(paths), "b can only be used once")
+	assert.Equal(t, []string{"ab1", "bd1"}, edgeIDs(paths[0]), "cheapest edges through b")
+	assert.Equal(t, []string{"a", "c", "d"}, paths[1].Names())
+
+	paths, err = G.VertexDisjointPaths("a", "d", &PathOptions{MaxHops: 1})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 0, len(paths), "no single hop path exists")
+
+	paths, err = G.EdgeDisjointPaths("a", "d", &PathOptions{ExcludeVertices: []string{"c"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, len(paths), "only the parallel edges remain")
+}
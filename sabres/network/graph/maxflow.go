@@ -0,0 +1,258 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// FlowOptions constrain a max flow computation.  The zero value uses the bw
+// property of every edge as its capacity.
+type FlowOptions struct {
+	// Capacity is the edge capacity, defaults to the bw property
+	Capacity WeightFunc
+	// Selector limits the flow to edges with this selector
+	Selector string
+	// ExcludeVertices are vertex names the flow may not pass through
+	ExcludeVertices []string
+	// ExcludeEdges are edge ids (uuid property or name) the flow may not use
+	ExcludeEdges []string
+}
+
+// EdgeFlow is the flow carried by an edge, in the direction From -> To.
+type EdgeFlow struct {
+	Edge  *Edge   `yaml:"edge" json:"edge"`
+	From  string  `yaml:"from" json:"from"`
+	To    string  `yaml:"to" json:"to"`
+	Value float64 `yaml:"value" json:"value"`
+}
+
+// Flow is the result of a max flow computation.  The min cut separates
+// SourceSide from the rest of the graph and its capacity equals Value.
+type Flow struct {
+	Value      float64     `yaml:"value" json:"value"`
+	Edges      []*EdgeFlow `yaml:"edges" json:"edges"`
+	Cut        []*Edge     `yaml:"cut" json:"cut"`
+	SourceSide []string    `yaml:"sourceSide" json:"sourceSide"`
+}
+
+// MaxFlow computes the maximum flow from src to dst, see MaxFlowSets.
+func (g *Graph) MaxFlow(src, dst string, opts *FlowOptions) (*Flow, error) {
+	return g.MaxFlowSets([]string{src}, []string{dst}, opts)
+}
+
+// MaxFlowSets computes the maximum flow from a set of sources to a set of
+// sinks along with a minimum cut between them.  Edges are undirected, each
+// can carry up to its capacity in either direction, and parallel edges add
+// their capacity.
+func (g *Graph) MaxFlowSets(sources, sinks []string, opts *FlowOptions) (*Flow, error) {
+	if opts == nil {
+		opts = &FlowOptions{}
+	}
+	capacity := opts.Capacity
+	if capacity == nil {
+		capacity = PropertyWeight("bw")
+	}
+
+	if len(sources) == 0 || len(sinks) == 0 {
+		return nil, fmt.Errorf("max flow needs at least one source and one sink")
+	}
+
+	spec := newSearchSpec(&PathOptions{
+		ExcludeVertices: opts.ExcludeVertices,
+		ExcludeEdges:    opts.ExcludeEdges,
+	})
+
+	index := make(map[string]int, len(g.Vertices))
+	for _, v := range g.Vertices {
+		if spec.exVerts[v.Name] {
+			continue
+		}
+		index[v.Name] = len(index)
+	}
+
+	isSource := make(map[string]bool)
+	for _, s := range sources {
+		if _, ok := index[s]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, s)
+		}
+		isSource[s] = true
+	}
+	for _, t := range sinks {
+		if _, ok := index[t]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, t)
+		}
+		if isSource[t] {
+			return nil, fmt.Errorf("vertex is both a source and a sink: %s", t)
+		}
+	}
+
+	// the super source and sink join the vertex sets
+	S, T := len(index), len(index)+1
+	net := newFlowNetwork(len(index) + 2)
+	for _, s := range sources {
+		net.addArc(S, index[s], math.Inf(1), 0, nil)
+	}
+	for _, t := range sinks {
+		net.addArc(index[t], T, math.Inf(1), 0, nil)
+	}
+
+	for _, e := range g.Edges {
+		if len(e.Vertices) != 2 || !spec.edgeAllowed(e) {
+			continue
+		}
+		if opts.Selector != "" && edgeSelector(e) != opts.Selector {
+			continue
+		}
+		u, ok := index[e.Vertices[0].Name]
+		if !ok {
+			continue
+		}
+		v, ok := index[e.Vertices[1].Name]
+		if !ok || u == v {
+			continue
+		}
+
+		c, err := capacity(e)
+		if err != nil {
+			return nil, err
+		}
+		if c < 0 {
+			return nil, fmt.Errorf("edge %s: negative capacity: %f", e.Name, c)
+		}
+
+		net.addArc(u, v, c, 0, e)
+		net.addArc(v, u, c, 0, e)
+	}
+
+	value := net.maxFlow(S, T)
+	reach := net.reachable(S)
+
+	f := &Flow{
+		Value:      value,
+		Edges:      make([]*EdgeFlow, 0),
+		Cut:        make([]*Edge, 0),
+		SourceSide: make([]string, 0),
+	}
+
+	for _, v := range g.Vertices {
+		if i, ok := index[v.Name]; ok && reach[i] {
+			f.SourceSide = append(f.SourceSide, v.Name)
+		}
+	}
+
+	// each edge has a forward and backward arc pair, net flow is the
+	// difference between the two
+	netFlow := make(map[*Edge]float64)
+	for i := 0; i < len(net.arcs); i += 2 {
+		a := net.arcs[i]
+		if a.edge == nil {
+			continue
+		}
+		if a.from == index[a.edge.Vertices[0].Name] {
+			netFlow[a.edge] += a.flow
+		} else {
+			netFlow[a.edge] -= a.flow
+		}
+		if reach[a.from] && !reach[a.to] {
+			f.Cut = append(f.Cut, a.edge)
+		}
+	}
+
+	for _, e := range g.Edges {
+		val, ok := netFlow[e]
+		if !ok || math.Abs(val) <= flowEpsilon {
+			continue
+		}
+		ef := &EdgeFlow{Edge: e, From: e.Vertices[0].Name, To: e.Vertices[1].Name, Value: val}
+		if val < 0 {
+			ef.From, ef.To, ef.Value = ef.To, ef.From, -val
+		}
+		f.Edges = append(f.Edges, ef)
+	}
+
+	return f, nil
+}
+
+// maxFlow is dinic's algorithm, returning the value of the maximum flow from
+// s to t.
+func (n *flowNetwork) maxFlow(s, t int) float64 {
+	total := 0.0
+	nodes := len(n.out)
+
+	for {
+		level := make([]int, nodes)
+		for i := range level {
+			level[i] = -1
+		}
+		level[s] = 0
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, i := range n.out[u] {
+				a := n.arcs[i]
+				if a.residual() > flowEpsilon && level[a.to] < 0 {
+					level[a.to] = level[u] + 1
+					queue = append(queue, a.to)
+				}
+			}
+		}
+
+		if level[t] < 0 {
+			return total
+		}
+
+		next := make([]int, nodes)
+		for {
+			f := n.blockingFlow(s, t, math.Inf(1), level, next)
+			if f <= flowEpsilon {
+				break
+			}
+			total += f
+			if math.IsInf(total, 1) {
+				return total
+			}
+		}
+	}
+}
+
+func (n *flowNetwork) blockingFlow(u, t int, limit float64, level, next []int) float64 {
+	if u == t {
+		return limit
+	}
+
+	for ; next[u] < len(n.out[u]); next[u]++ {
+		i := n.out[u][next[u]]
+		a := n.arcs[i]
+		if a.residual() <= flowEpsilon || level[a.to] != level[u]+1 {
+			continue
+		}
+
+		f := n.blockingFlow(a.to, t, math.Min(limit, a.residual()), level, next)
+		if f > flowEpsilon {
+			n.push(i, f)
+			return f
+		}
+	}
+
+	return 0
+}
+
+// reachable marks the nodes reachable from s in the residual network.
+func (n *flowNetwork) reachable(s int) []bool {
+	seen := make([]bool, len(n.out))
+	seen[s] = true
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range n.out[u] {
+			a := n.arcs[i]
+			if a.residual() > flowEpsilon && !seen[a.to] {
+				seen[a.to] = true
+				queue = append(queue, a.to)
+			}
+		}
+	}
+	return seen
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxFlow(t *testing.T) {
+	G := testGraph(t, "test-flow")
+
+	f, err := G.MaxFlow("a", "d", nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 12.0, f.Value, "parallel a=b edges add their capacity")
+	assert.Equal(t, []string{"bd", "ac"}, bridgeIDs(f.Cut))
+	assert.Equal(t, []string{"a", "b"}, f.SourceSide)
+
+	// flow is conserved at b
+	in, out := 0.0, 0.0
+	for _, ef := range f.Edges {
+		if ef.To == "b" {
+			in += ef.Value
+		}
+		if ef.From == "b" {
+			out += ef.Value
+		}
+	}
+	assert.Equal(t, 8.0, in)
+	assert.Equal(t, in, out)
+
+	// undirected, the reverse flow is the same
+	r, err := G.MaxFlow("d", "a", nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, f.Value, r.Value)
+
+	x, err := G.MaxFlow("a", "d", &FlowOptions{Selector: "x"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 8.0, x.Value)
+
+	ex, err := G.MaxFlow("a", "d", &FlowOptions{ExcludeVertices: []string{"c"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 8.0, ex.Value)
+	assert.Equal(t, []string{"bd"}, bridgeIDs(ex.Cut))
+}
+
+func TestMaxFlowSets(t *testing.T) {
+	G := testGraph(t, "test-flow")
+
+	f, err := G.MaxFlowSets([]string{"a"}, []string{"b", "c"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 14.0, f.Value)
+
+	_, err = G.MaxFlowSets([]string{"a"}, []string{"a", "d"}, nil)
+	assert.NotNil(t, err, "a vertex can not be a source and a sink")
+
+	_, err = G.MaxFlow("a", "e", nil)
+	assert.True(t, errors.Is(err, ErrVertexNotFound))
+
+	M := testGraph(t, "test-multi")
+	_, err = M.MaxFlow("a", "d", nil)
+	assert.NotNil(t, err, "edges without bw have no capacity")
+}
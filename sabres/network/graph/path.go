@@ -0,0 +1,404 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrNoPath         = errors.New("no path found")
+	ErrNegativeWeight = errors.New("negative edge weight")
+	ErrUnknownMetric  = errors.New("unknown path metric")
+)
+
+// DefaultReferenceBandwidth is the bandwidth an edge must have to cost 1
+// when weighting by bandwidth, the same idea as the ospf reference bw.
+var DefaultReferenceBandwidth float64 = 100000
+
+// WeightFunc returns the cost of traversing an edge.  Costs must not be
+// negative.
+type WeightFunc func(e *Edge) (float64, error)
+
+// HopWeight counts every edge as a single hop.
+func HopWeight(e *Edge) (float64, error) {
+	return 1, nil
+}
+
+// PropertyWeight uses a numeric edge property (lat, jit) as the cost.
+func PropertyWeight(key string) WeightFunc {
+	return func(e *Edge) (float64, error) {
+		return e.floatProperty(key)
+	}
+}
+
+// InverseWeight uses ref/value of a numeric edge property as the cost, so
+// that larger values (bw) are cheaper to traverse.
+func InverseWeight(key string, ref float64) WeightFunc {
+	return func(e *Edge) (float64, error) {
+		val, err := e.floatProperty(key)
+		if err != nil {
+			return 0, err
+		}
+
+		if val <= 0 {
+			return math.Inf(1), nil
+		}
+
+		return ref / val, nil
+	}
+}
+
+// MetricWeight returns the weight function for one of the edge properties
+// written by the network service: lat, jit, bw, or hops.
+func MetricWeight(metric string) (WeightFunc, error) {
+	switch metric {
+	case "", "hops":
+		return HopWeight, nil
+	case "lat", "latency":
+		return PropertyWeight("lat"), nil
+	case "jit", "jitter":
+		return PropertyWeight("jit"), nil
+	case "bw", "bandwidth":
+		return InverseWeight("bw", DefaultReferenceBandwidth), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMetric, metric)
+}
+
+// PathOptions constrain a path search.  The zero value finds the path
+// with the fewest hops.
+type PathOptions struct {
+	// Weight is the edge cost, defaults to HopWeight
+	Weight WeightFunc
+	// Heuristic turns the search into A*.  It must never overestimate the
+	// remaining cost to the target.
+	Heuristic func(v *Vertex) float64
+	// MaxHops limits the number of edges in the path, 0 is unlimited
+	MaxHops int
+	// ExcludeVertices are vertex names the path may not pass through
+	ExcludeVertices []string
+	// ExcludeEdges are edge ids (uuid property or name) the path may not use
+	ExcludeEdges []string
+}
+
+// Path is an ordered walk through the graph.  Edges[i] connects
+// Vertices[i] and Vertices[i+1].
+type Path struct {
+	Vertices []*Vertex `yaml:"vertices" json:"vertices"`
+	Edges    []*Edge   `yaml:"edges" json:"edges"`
+	Cost     float64   `yaml:"cost" json:"cost"`
+}
+
+// Hops returns the number of edges in the path
+func (p *Path) Hops() int {
+	return len(p.Edges)
+}
+
+// Names returns the ordered vertex names of the path
+func (p *Path) Names() []string {
+	names := make([]string, 0, len(p.Vertices))
+	for _, v := range p.Vertices {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// ID returns the identifier of an edge.  Multigraph edges between the same
+// vertices share a name, so the uuid property is preferred when set.
+func (e *Edge) ID() string {
+	if e.Properties != nil {
+		if id, ok := e.Properties["uuid"]; ok && id != "" {
+			return id
+		}
+	}
+	return e.Name
+}
+
+// Other returns the name of the endpoint of the edge that is not name.
+func (e *Edge) Other(name string) string {
+	if len(e.Vertices) < 2 {
+		return ""
+	}
+	if e.Vertices[0].Name == name {
+		return e.Vertices[1].Name
+	}
+	return e.Vertices[0].Name
+}
+
+func (e *Edge) floatProperty(key string) (float64, error) {
+	if e.Properties == nil {
+		return 0, fmt.Errorf("edge %s: missing property %s", e.Name, key)
+	}
+
+	if _, ok := e.Properties[key]; !ok {
+		return 0, fmt.Errorf("edge %s: missing property %s", e.Name, key)
+	}
+
+	// parsing through the schema converts units, e.g. bw=10Gbps
+	val, err := e.Attr(key)
+	if err != nil {
+		return 0, fmt.Errorf("edge %s: property %s: %v", e.Name, key, err)
+	}
+	if !val.Numeric() {
+		return 0, fmt.Errorf("edge %s: property %s: %s is not a number", e.Name, key, val)
+	}
+
+	return val.Num, nil
+}
+
+// ShortestPath finds the least cost path from src to dst honouring the
+// hop limit and exclusions in opts.  opts may be nil.
+func (g *Graph) ShortestPath(src, dst string, opts *PathOptions) (*Path, error) {
+	return g.search(src, dst, newSearchSpec(opts))
+}
+
+// searchSpec is the internal form of PathOptions, it allows edges to be
+// excluded by identity, which the multigraph algorithms need.
+type searchSpec struct {
+	weight    WeightFunc
+	heuristic func(v *Vertex) float64
+	maxHops   int
+	exVerts   map[string]bool
+	exEdgeIDs map[string]bool
+	exEdges   map[*Edge]bool
+}
+
+func newSearchSpec(opts *PathOptions) *searchSpec {
+	if opts == nil {
+		opts = &PathOptions{}
+	}
+
+	spec := &searchSpec{
+		weight:    opts.Weight,
+		heuristic: opts.Heuristic,
+		maxHops:   opts.MaxHops,
+		exVerts:   make(map[string]bool),
+		exEdgeIDs: make(map[string]bool),
+		exEdges:   make(map[*Edge]bool),
+	}
+	if spec.weight == nil {
+		spec.weight = HopWeight
+	}
+	for _, v := range opts.ExcludeVertices {
+		spec.exVerts[v] = true
+	}
+	for _, e := range opts.ExcludeEdges {
+		spec.exEdgeIDs[e] = true
+	}
+
+	return spec
+}
+
+func (s *searchSpec) clone() *searchSpec {
+	c := &searchSpec{
+		weight:    s.weight,
+		heuristic: s.heuristic,
+		maxHops:   s.maxHops,
+		exVerts:   make(map[string]bool, len(s.exVerts)),
+		exEdgeIDs: make(map[string]bool, len(s.exEdgeIDs)),
+		exEdges:   make(map[*Edge]bool, len(s.exEdges)),
+	}
+	for k, v := range s.exVerts {
+		c.exVerts[k] = v
+	}
+	for k, v := range s.exEdgeIDs {
+		c.exEdgeIDs[k] = v
+	}
+	for k, v := range s.exEdges {
+		c.exEdges[k] = v
+	}
+	return c
+}
+
+// cost returns the weight of an edge, checking it is usable.
+func (s *searchSpec) cost(e *Edge) (float64, error) {
+	w, err := s.weight(e)
+	if err != nil {
+		return 0, err
+	}
+	if w < 0 {
+		return 0, fmt.Errorf("%w: edge %s: %f", ErrNegativeWeight, e.Name, w)
+	}
+	return w, nil
+}
+
+func (s *searchSpec) edgeAllowed(e *Edge) bool {
+	if s.exEdges[e] {
+		return false
+	}
+	if len(s.exEdgeIDs) > 0 && s.exEdgeIDs[e.ID()] {
+		return false
+	}
+	return true
+}
+
+type searchState struct {
+	vertex string
+	hops   int
+}
+
+type searchLabel struct {
+	state searchState
+	cost  float64
+	prio  float64
+	seq   int
+	prev  *searchLabel
+	edge  *Edge
+	index int
+}
+
+type searchQueue []*searchLabel
+
+func (q searchQueue) Len() int { return len(q) }
+func (q searchQueue) Less(i, j int) bool {
+	if q[i].prio == q[j].prio {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].prio < q[j].prio
+}
+func (q searchQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *searchQueue) Push(x interface{}) {
+	l := x.(*searchLabel)
+	l.index = len(*q)
+	*q = append(*q, l)
+}
+func (q *searchQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	l := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return l
+}
+
+// search is dijkstra (or A* with a heuristic) over (vertex, hops) states.
+// When there is no hop limit the hop count is not part of the state.
+func (g *Graph) search(src, dst string, spec *searchSpec) (*Path, error) {
+	g.ensureIndex()
+	verts := g.vertexIndex
+
+	srcV, ok := verts[src]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, src)
+	}
+	if _, ok := verts[dst]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, dst)
+	}
+
+	if spec.exVerts[src] || spec.exVerts[dst] {
+		return nil, fmt.Errorf("%w: endpoint excluded", ErrNoPath)
+	}
+
+	if src == dst {
+		return &Path{Vertices: []*Vertex{srcV}, Edges: []*Edge{}}, nil
+	}
+
+	h := func(name string) float64 {
+		if spec.heuristic == nil {
+			return 0
+		}
+		return spec.heuristic(verts[name])
+	}
+
+	adj := g.adjIndex
+
+	seq := 0
+	start := &searchLabel{state: searchState{vertex: src}, prio: h(src)}
+	best := map[searchState]*searchLabel{start.state: start}
+	done := make(map[searchState]bool)
+
+	q := &searchQueue{}
+	heap.Push(q, start)
+
+	for q.Len() > 0 {
+		cur := heap.Pop(q).(*searchLabel)
+		if done[cur.state] {
+			continue
+		}
+		done[cur.state] = true
+
+		if cur.state.vertex == dst {
+			return cur.path(verts), nil
+		}
+
+		hops := cur.state.hops + 1
+		if spec.maxHops > 0 && hops > spec.maxHops {
+			continue
+		}
+
+		for _, e := range adj[cur.state.vertex] {
+			if !spec.edgeAllowed(e) {
+				continue
+			}
+
+			next := e.Other(cur.state.vertex)
+			if spec.exVerts[next] {
+				continue
+			}
+			if _, ok := verts[next]; !ok {
+				continue
+			}
+
+			w, err := spec.cost(e)
+			if err != nil {
+				return nil, err
+			}
+			if math.IsInf(w, 1) {
+				continue
+			}
+
+			st := searchState{vertex: next}
+			if spec.maxHops > 0 {
+				st.hops = hops
+			}
+			if done[st] {
+				continue
+			}
+
+			cost := cur.cost + w
+			if old, ok := best[st]; ok && old.cost <= cost {
+				continue
+			}
+
+			seq++
+			l := &searchLabel{
+				state: st,
+				cost:  cost,
+				prio:  cost + h(next),
+				seq:   seq,
+				prev:  cur,
+				edge:  e,
+			}
+			best[st] = l
+			heap.Push(q, l)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s -> %s", ErrNoPath, src, dst)
+}
+
+func (l *searchLabel) path(verts map[string]*Vertex) *Path {
+	p := &Path{Cost: l.cost}
+	for cur := l; cur != nil; cur = cur.prev {
+		p.Vertices = append(p.Vertices, verts[cur.state.vertex])
+		if cur.edge != nil {
+			p.Edges = append(p.Edges, cur.edge)
+		}
+	}
+
+	// walked from the target back to the source
+	for i, j := 0, len(p.Vertices)-1; i < j; i, j = i+1, j-1 {
+		p.Vertices[i], p.Vertices[j] = p.Vertices[j], p.Vertices[i]
+	}
+	for i, j := 0, len(p.Edges)-1; i < j; i, j = i+1, j-1 {
+		p.Edges[i], p.Edges[j] = p.Edges[j], p.Edges[i]
+	}
+
+	return p
+}
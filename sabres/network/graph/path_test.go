@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortestPathMetrics(t *testing.T) {
+	G := testGraph(t, "test-path")
+
+	tests := []struct {
+		metric string
+		path   []string
+		cost   float64
+	}{
+		{"hops", []string{"a", "d"}, 1},
+		{"lat", []string{"a", "b", "d"}, 2},
+		{"jit", []string{"a", "c", "d"}, 2},
+		{"bw", []string{"a", "c", "d"}, 2 * DefaultReferenceBandwidth / 1000},
+	}
+
+	for _, tt := range tests {
+		w, err := MetricWeight(tt.metric)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		p, err := G.ShortestPath("a", "d", &PathOptions{Weight: w})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.metric, err)
+		}
+
+		assert.Equal(t, tt.path, p.Names(), "path by %s", tt.metric)
+		assert.Equal(t, tt.cost, p.Cost, "cost by %s", tt.metric)
+		assert.Equal(t, len(p.Vertices)-1, p.Hops(), "edges between every vertex")
+	}
+
+	_, err := MetricWeight("distance")
+	assert.True(t, errors.Is(err, ErrUnknownMetric), "distance is not an edge metric")
+}
+
+func TestShortestPathConstraints(t *testing.T) {
+	G := testGraph(t, "test-path")
+	lat := PropertyWeight("lat")
+
+	p, err := G.ShortestPath("a", "d", &PathOptions{Weight: lat, MaxHops: 1})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "d"}, p.Names(), "hop limit forces the direct edge")
+	assert.Equal(t, float64(20), p.Cost)
+
+	p, err = G.ShortestPath("a", "d", &PathOptions{Weight: lat, ExcludeVertices: []string{"b"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "c", "d"}, p.Names(), "b is excluded")
+
+	p, err = G.ShortestPath("a", "d", &PathOptions{Weight: lat, ExcludeEdges: []string{"bd", "cd"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "d"}, p.Names(), "only the direct edge remains")
+
+	_, err = G.ShortestPath("a", "d", &PathOptions{ExcludeEdges: []string{"bd", "cd", "ad"}})
+	assert.True(t, errors.Is(err, ErrNoPath), "d is unreachable")
+
+	_, err = G.ShortestPath("a", "e", nil)
+	assert.True(t, errors.Is(err, ErrVertexNotFound), "e is not in the graph")
+
+	p, err = G.ShortestPath("a", "a", nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 0, p.Hops(), "path to self has no edges")
+}
+
+func TestShortestPathAStar(t *testing.T) {
+	G := testGraph(t, "test-path")
+
+	// admissible heuristic: one hop of the cheapest latency unless at d
+	h := func(v *Vertex) float64 {
+		if v.Name == "d" {
+			return 0
+		}
+		return 1
+	}
+
+	p, err := G.ShortestPath("a", "d", &PathOptions{Weight: PropertyWeight("lat"), Heuristic: h})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "b", "d"}, p.Names(), "A* finds the same path")
+	assert.Equal(t, float64(2), p.Cost)
+}
+
+func TestShortestPathMissingProperty(t *testing.T) {
+	G := testGraph(t, "test-path")
+
+	_, err := G.AddEdge(&Vertex{Name: "a"}, &Vertex{Name: "e"}, map[string]string{"uuid": "ae"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = G.ShortestPath("a", "e", &PathOptions{Weight: PropertyWeight("lat")})
+	assert.NotNil(t, err, "edge ae has no latency")
+
+	p, err := G.ShortestPath("a", "e", nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "e"}, p.Names(), "hops need no properties")
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/goccy/go-graphviz"
+	"github.com/goccy/go-graphviz/cgraph"
+)
+
+// Formats Render can produce, FormatDot is the laid out dot text.
+const (
+	FormatSVG = "svg"
+	FormatPNG = "png"
+)
+
+// edgePalette colours edges by the value of RenderOptions.ColorBy, values
+// take colours in the order they are first seen.
+var edgePalette = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#9467bd", "#8c564b",
+	"#e377c2", "#7f7f7f", "#bcbd22", "#17becf", "#aec7e8",
+}
+
+const highlightColor = "#d62728"
+
+// RenderOptions style a rendered graph.  The zero value draws every vertex
+// and edge, labelling edges with their uuid and bw like DotViz.
+type RenderOptions struct {
+	// Format is svg, png or dot, defaults to svg
+	Format string
+	// ColorBy is the edge property that picks the edge colour, e.g. selector
+	ColorBy string
+	// WidthBy is the numeric edge property that scales the edge width, e.g. bw
+	WidthBy string
+	// VertexLabels are vertex properties listed under the name, e.g. cpu, mem
+	VertexLabels []string
+	// EdgeLabels are the edge properties in the edge label, defaults to uuid, bw
+	EdgeLabels []string
+	// Highlight are vertex names and edge ids to draw out, e.g. a slice's path
+	Highlight []string
+}
+
+// Render draws the graph with graphviz.  Parallel edges are drawn
+// separately so that each network can be told apart.
+func (g *Graph) Render(opts *RenderOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &RenderOptions{}
+	}
+
+	var format graphviz.Format
+	switch strings.ToLower(opts.Format) {
+	case "", FormatSVG:
+		format = graphviz.SVG
+	case FormatPNG:
+		format = graphviz.PNG
+	case FormatDot:
+		format = graphviz.XDOT
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	edgeLabels := opts.EdgeLabels
+	if edgeLabels == nil {
+		edgeLabels = []string{"uuid", "bw"}
+	}
+
+	highlight := make(map[string]bool, len(opts.Highlight))
+	for _, h := range opts.Highlight {
+		highlight[h] = true
+	}
+
+	gviz := graphviz.New()
+	defer gviz.Close()
+
+	gvizObj, err := gviz.Graph(graphviz.UnDirected)
+	if err != nil {
+		return nil, err
+	}
+	defer gvizObj.Close()
+
+	m := make(map[string]*cgraph.Node)
+	for _, v := range g.Vertices {
+		n, err := gvizObj.CreateNode(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		n.SetLabel(vertexLabel(v, opts.VertexLabels))
+		if highlight[v.Name] {
+			n.SetStyle(cgraph.FilledNodeStyle)
+			n.SetColor(highlightColor)
+			n.SetFillColor("#fbe3e3")
+			n.SetPenWidth(2)
+		}
+		m[v.Name] = n
+	}
+
+	colors := make(map[string]string)
+	widths := edgeWidths(g.Edges, opts.WidthBy)
+
+	for i, e := range g.Edges {
+		if len(e.Vertices) != 2 {
+			continue
+		}
+		v1, ok := m[e.Vertices[0].Name]
+		if !ok {
+			return nil, fmt.Errorf("Vertex not found in graph: %s", e.Vertices[0].Name)
+		}
+		v2, ok := m[e.Vertices[1].Name]
+		if !ok {
+			return nil, fmt.Errorf("Vertex not found in graph: %s", e.Vertices[1].Name)
+		}
+
+		// edges with the same key are merged, so key on the position
+		ge, err := gvizObj.CreateEdge(fmt.Sprintf("%d", i), v1, v2)
+		if err != nil {
+			return nil, err
+		}
+
+		if label := edgeLabel(e, edgeLabels); label != "" {
+			ge.SetLabel(label)
+		}
+
+		if opts.ColorBy != "" {
+			if val, ok := e.Properties[opts.ColorBy]; ok {
+				c, ok := colors[val]
+				if !ok {
+					c = edgePalette[len(colors)%len(edgePalette)]
+					colors[val] = c
+				}
+				ge.SetColor(c)
+				ge.SetFontColor(c)
+			}
+		}
+
+		width := 1.0
+		if w, ok := widths[e]; ok {
+			width = w
+		}
+
+		if highlight[e.ID()] {
+			ge.SetColor(highlightColor)
+			ge.SetFontColor(highlightColor)
+			ge.SetStyle(cgraph.BoldEdgeStyle)
+			width += 2
+		}
+		ge.SetPenWidth(width)
+	}
+
+	var buf bytes.Buffer
+	if err := gviz.Render(gvizObj, format, &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func vertexLabel(v *Vertex, keys []string) string {
+	lines := []string{v.Name}
+	for _, k := range keys {
+		if val, ok := v.Properties[k]; ok {
+			lines = append(lines, fmt.Sprintf("%s: %s", k, val))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func edgeLabel(e *Edge, keys []string) string {
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if val, ok := e.Properties[k]; ok {
+			parts = append(parts, fmt.Sprintf("%s: %s", k, val))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// edgeWidths scales a numeric edge property to a pen width between 1 and 5.
+// Bandwidths span orders of magnitude, so the scale is logarithmic.
+func edgeWidths(edges []*Edge, key string) map[*Edge]float64 {
+	widths := make(map[*Edge]float64)
+	if key == "" {
+		return widths
+	}
+
+	vals := make(map[*Edge]float64)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, e := range edges {
+		v, err := e.floatProperty(key)
+		if err != nil || v <= 0 {
+			continue
+		}
+		vals[e] = math.Log(v)
+		lo = math.Min(lo, vals[e])
+		hi = math.Max(hi, vals[e])
+	}
+
+	for e, v := range vals {
+		if hi == lo {
+			widths[e] = 3
+			continue
+		}
+		widths[e] = 1 + 4*(v-lo)/(hi-lo)
+	}
+
+	return widths
+}
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	G := testGraph(t, "test-flow")
+	for _, v := range G.Vertices {
+		v.Properties = map[string]string{"cpu": "8", "mem": "16"}
+	}
+
+	out, err := G.Render(&RenderOptions{
+		Format:       FormatDot,
+		ColorBy:      "selector",
+		WidthBy:      "bw",
+		VertexLabels: []string{"cpu"},
+		Highlight:    []string{"a", "ab2", "b"},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	D, err := FromDot(out)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ab, _ := D.FindEdge(&Edge{Vertices: []*Vertex{{Name: "a"}, {Name: "b"}}})
+	assert.Equal(t, 2, len(ab), "parallel edges are drawn separately")
+
+	a, _ := D.GetVertex("a")
+	assert.Contains(t, a.Properties["label"], "cpu: 8")
+	assert.Equal(t, "filled", a.Properties["style"], "highlighted vertex")
+
+	colors := make(map[string]string)
+	for _, e := range D.Edges {
+		colors[e.Properties["label"]] = e.Properties["color"]
+	}
+	assert.Equal(t, "#1f77b4", colors["uuid: ab1 | bw: 4"], "selector x")
+	assert.Equal(t, "#1f77b4", colors["uuid: cd | bw: 6"], "selector x")
+	assert.Equal(t, highlightColor, colors["uuid: ab2 | bw: 6"], "highlighted edge")
+
+	widths := edgeWidths(G.Edges, "bw")
+	assert.Equal(t, 1.0, widths[G.GetEdgesByID("ab1")[0]], "smallest bw")
+	assert.Equal(t, 5.0, widths[G.GetEdgesByID("bd")[0]], "largest bw")
+
+	svg, err := G.Render(nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, bytes.Contains(svg, []byte("<svg")))
+
+	png, err := G.Render(&RenderOptions{Format: FormatPNG})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG")))
+
+	_, err = G.Render(&RenderOptions{Format: "gif"})
+	assert.True(t, errors.Is(err, ErrUnknownFormat))
+}
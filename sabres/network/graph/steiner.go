@@ -0,0 +1,631 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SteinerExactLimit is the largest number of terminals SteinerTree solves
+// exactly.  The exact search grows as 3^terminals, above the limit the
+// tree is at most twice the minimum cost.
+var SteinerExactLimit = 8
+
+// SteinerExactWork bounds the exact search, 3^terminals times the vertices
+// near enough to the terminals to be in the tree.  A larger search uses the
+// approximation as well.
+var SteinerExactWork = 20000000
+
+// Tree is a subgraph without cycles connecting a set of terminals.
+type Tree struct {
+	Terminals []string  `yaml:"terminals" json:"terminals"`
+	Vertices  []*Vertex `yaml:"vertices" json:"vertices"`
+	Edges     []*Edge   `yaml:"edges" json:"edges"`
+	Cost      float64   `yaml:"cost" json:"cost"`
+}
+
+// Names returns the vertex names of the tree
+func (t *Tree) Names() []string {
+	names := make([]string, 0, len(t.Vertices))
+	for _, v := range t.Vertices {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// Neighbors returns the vertices joined to name by a tree edge, with the
+// edge joining them.
+func (t *Tree) Neighbors(name string) map[string]*Edge {
+	n := make(map[string]*Edge)
+	for _, e := range t.Edges {
+		if len(e.Vertices) != 2 {
+			continue
+		}
+		if e.Vertices[0].Name == name || e.Vertices[1].Name == name {
+			n[e.Other(name)] = e
+		}
+	}
+	return n
+}
+
+// Leaves returns the vertices with a single tree edge in name order.
+func (t *Tree) Leaves() []string {
+	degree := make(map[string]int)
+	for _, e := range t.Edges {
+		for _, v := range e.Vertices {
+			degree[v.Name]++
+		}
+	}
+
+	leaves := make([]string, 0)
+	for name, d := range degree {
+		if d == 1 {
+			leaves = append(leaves, name)
+		}
+	}
+	sort.Strings(leaves)
+	return leaves
+}
+
+// Path returns the only path through the tree from src to dst.
+func (t *Tree) Path(src, dst string) (*Path, error) {
+	verts := make(map[string]*Vertex, len(t.Vertices))
+	for _, v := range t.Vertices {
+		verts[v.Name] = v
+	}
+	if _, ok := verts[src]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, src)
+	}
+	if _, ok := verts[dst]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, dst)
+	}
+
+	// walk out from dst so the path reads forwards from src
+	via := map[string]*Edge{dst: nil}
+	queue := []string{dst}
+	for len(queue) > 0 {
+		if _, found := via[src]; found {
+			break
+		}
+		cur := queue[0]
+		queue = queue[1:]
+		for next, e := range t.Neighbors(cur) {
+			if _, seen := via[next]; !seen {
+				via[next] = e
+				queue = append(queue, next)
+			}
+		}
+	}
+	if _, ok := via[src]; !ok {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrNoPath, src, dst)
+	}
+
+	p := &Path{Vertices: []*Vertex{verts[src]}, Edges: []*Edge{}}
+	for cur := src; cur != dst; {
+		e := via[cur]
+		cur = e.Other(cur)
+		p.Vertices = append(p.Vertices, verts[cur])
+		p.Edges = append(p.Edges, e)
+	}
+
+	return p, nil
+}
+
+// SteinerTree finds the least cost tree connecting the terminals.  Up to
+// SteinerExactLimit terminals, and SteinerExactWork, the tree is the
+// minimum, with more it is found with the Kou, Markowsky and Berman
+// approximation.  The weight and
+// exclusions of opts are used, MaxHops and Heuristic do not apply to trees.
+func (g *Graph) SteinerTree(terminals []string, opts *PathOptions) (*Tree, error) {
+	if len(terminals) == 0 {
+		return nil, fmt.Errorf("steiner tree needs at least one terminal")
+	}
+
+	sg, err := newSteinerGraph(g, newSearchSpec(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]int, 0, len(terminals))
+	seen := make(map[string]bool)
+	for _, name := range terminals {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if _, ok := g.GetVertex(name); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrVertexNotFound, name)
+		}
+		i, ok := sg.index[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: terminal %s excluded", ErrNoPath, name)
+		}
+		terms = append(terms, i)
+	}
+
+	var edges map[*Edge]bool
+	if len(terms) <= SteinerExactLimit {
+		edges, err = sg.dreyfusWagner(terms)
+	} else {
+		edges, err = sg.kmb(terms)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := sg.tree(terms, edges)
+	for _, i := range terms {
+		t.Terminals = append(t.Terminals, sg.names[i])
+	}
+
+	return t, nil
+}
+
+type steinerArc struct {
+	to int
+	w  float64
+	e  *Edge
+}
+
+// steinerGraph is the usable part of the graph with integer vertices,
+// parallel edges are reduced to the cheapest.
+type steinerGraph struct {
+	g     *Graph
+	names []string
+	index map[string]int
+	adj   [][]steinerArc
+	cost  map[*Edge]float64
+}
+
+func newSteinerGraph(g *Graph, spec *searchSpec) (*steinerGraph, error) {
+	g.ensureIndex()
+
+	sg := &steinerGraph{g: g, index: make(map[string]int), cost: make(map[*Edge]float64)}
+	for _, v := range g.Vertices {
+		if spec.exVerts[v.Name] {
+			continue
+		}
+		if _, ok := sg.index[v.Name]; ok {
+			continue
+		}
+		sg.index[v.Name] = len(sg.names)
+		sg.names = append(sg.names, v.Name)
+	}
+	sg.adj = make([][]steinerArc, len(sg.names))
+
+	cheapest := make(map[[2]int]steinerArc)
+	for _, e := range g.Edges {
+		if len(e.Vertices) != 2 || !spec.edgeAllowed(e) {
+			continue
+		}
+		u, ok := sg.index[e.Vertices[0].Name]
+		if !ok {
+			continue
+		}
+		v, ok := sg.index[e.Vertices[1].Name]
+		if !ok || u == v {
+			continue
+		}
+
+		w, err := spec.cost(e)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsInf(w, 1) {
+			continue
+		}
+		sg.cost[e] = w
+
+		key := [2]int{u, v}
+		if v < u {
+			key = [2]int{v, u}
+		}
+		if old, ok := cheapest[key]; !ok || w < old.w {
+			cheapest[key] = steinerArc{w: w, e: e}
+		}
+	}
+
+	// keep the order of g.Edges so ties break the same way every time
+	for _, e := range g.Edges {
+		if _, ok := sg.cost[e]; !ok {
+			continue
+		}
+		u, v := sg.index[e.Vertices[0].Name], sg.index[e.Vertices[1].Name]
+		key := [2]int{u, v}
+		if v < u {
+			key = [2]int{v, u}
+		}
+		if cheapest[key].e != e {
+			continue
+		}
+		sg.adj[u] = append(sg.adj[u], steinerArc{to: v, w: sg.cost[e], e: e})
+		sg.adj[v] = append(sg.adj[v], steinerArc{to: u, w: sg.cost[e], e: e})
+	}
+
+	return sg, nil
+}
+
+type distItem struct {
+	v int
+	d float64
+}
+
+// distQueue is a min heap of vertices by distance, a vertex may be queued
+// again with a lower distance so stale entries are skipped when popped.
+type distQueue []distItem
+
+func (q distQueue) Len() int            { return len(q) }
+func (q distQueue) Less(i, j int) bool  { return q[i].d < q[j].d }
+func (q distQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distQueue) Push(x interface{}) { *q = append(*q, x.(distItem)) }
+func (q *distQueue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+// shortest returns the distances from src and the arc used to reach each
+// vertex, which leads back towards src.
+func (sg *steinerGraph) shortest(src int) ([]float64, []*steinerArc) {
+	n := len(sg.names)
+	dist := make([]float64, n)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	via := make([]*steinerArc, n)
+	done := make([]bool, n)
+
+	dist[src] = 0
+	q := &distQueue{{v: src}}
+	for q.Len() > 0 {
+		it := heap.Pop(q).(distItem)
+		if done[it.v] {
+			continue
+		}
+		done[it.v] = true
+
+		for _, a := range sg.adj[it.v] {
+			if d := it.d + a.w; d < dist[a.to] {
+				dist[a.to] = d
+				via[a.to] = &steinerArc{to: it.v, w: a.w, e: a.e}
+				heap.Push(q, distItem{v: a.to, d: d})
+			}
+		}
+	}
+
+	return dist, via
+}
+
+// addPath adds the edges of the shortest path from v back to the source of
+// via.
+func addPath(edges map[*Edge]bool, via []*steinerArc, v int) {
+	for via[v] != nil {
+		edges[via[v].e] = true
+		v = via[v].to
+	}
+}
+
+// dreyfusWagner finds the minimum tree.  best[S][v] is the cost of the
+// cheapest tree connecting the terminals in S and v, it either joins two
+// trees at v or extends a tree at u by the shortest path from u to v.
+//
+// Shortest paths are only found from the terminals.  The tree kmb finds
+// bounds the minimum, so a vertex further than that from any terminal is
+// left out of the search, and trees are extended by a Dijkstra over what
+// is left rather than by the distances between every two vertices.
+func (sg *steinerGraph) dreyfusWagner(terms []int) (map[*Edge]bool, error) {
+	n, k := len(sg.names), len(terms)
+
+	if k == 1 {
+		return map[*Edge]bool{}, nil
+	}
+
+	// two terminals are joined by the shortest path between them
+	if k == 2 {
+		dist, via := sg.shortest(terms[0])
+		if math.IsInf(dist[terms[1]], 1) {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrNoPath, sg.names[terms[0]], sg.names[terms[1]])
+		}
+		edges := make(map[*Edge]bool)
+		addPath(edges, via, terms[1])
+		return edges, nil
+	}
+
+	dists := make([][]float64, k)
+	vias := make([][]*steinerArc, k)
+	for i, t := range terms {
+		dists[i], vias[i] = sg.shortest(t)
+	}
+
+	approx, err := sg.kmbPaths(terms, dists, vias)
+	if err != nil {
+		return nil, err
+	}
+	bound := sg.tree(terms, approx).Cost
+	bound += 1e-9 * (1 + bound)
+
+	// local[v] is the place of v among the vertices searched, or -1
+	local := make([]int, n)
+	verts := make([]int, 0)
+	for v := 0; v < n; v++ {
+		local[v] = -1
+		near := true
+		for i := range terms {
+			if dists[i][v] > bound {
+				near = false
+				break
+			}
+		}
+		if near {
+			local[v] = len(verts)
+			verts = append(verts, v)
+		}
+	}
+	m := len(verts)
+
+	work := float64(m)
+	for range terms {
+		work *= 3
+	}
+	if work > float64(SteinerExactWork) {
+		return approx, nil
+	}
+
+	// split[S][v] is the subset joined at v, from[S][v] the vertex the tree
+	// was extended from to v, back towards where it was joined, or -1
+	full := 1<<k - 1
+	best := make([][]float64, full+1)
+	split := make([][]uint16, full+1)
+	from := make([][]int32, full+1)
+	for i := range terms {
+		best[1<<i] = make([]float64, m)
+		for lv, v := range verts {
+			best[1<<i][lv] = dists[i][v]
+		}
+	}
+
+	for s := 1; s <= full; s++ {
+		if s&(s-1) == 0 {
+			continue
+		}
+
+		best[s] = make([]float64, m)
+		split[s] = make([]uint16, m)
+		from[s] = make([]int32, m)
+
+		// each split once, the part holding the lowest terminal first
+		low := s & -s
+		for lv := 0; lv < m; lv++ {
+			best[s][lv] = math.Inf(1)
+			for sub := (s - 1) & s; sub > 0; sub = (sub - 1) & s {
+				if sub&low == 0 {
+					continue
+				}
+				if c := best[sub][lv] + best[s^sub][lv]; c < best[s][lv] {
+					best[s][lv] = c
+					split[s][lv] = uint16(sub)
+				}
+			}
+		}
+
+		sg.extend(best[s], from[s], verts, local)
+	}
+
+	edges := make(map[*Edge]bool)
+	var build func(s, v int)
+	build = func(s, v int) {
+		if s&(s-1) == 0 {
+			// a single terminal, joined by its shortest path
+			for i := range terms {
+				if s == 1<<i {
+					addPath(edges, vias[i], v)
+				}
+			}
+			return
+		}
+
+		for u := from[s][local[v]]; u >= 0; u = from[s][local[v]] {
+			edges[sg.arc(verts[u], v).e] = true
+			v = verts[u]
+		}
+
+		sub := int(split[s][local[v]])
+		build(sub, v)
+		build(s^sub, v)
+	}
+	build(full, terms[0])
+
+	return edges, nil
+}
+
+// extend lowers the cost of the trees in best by the shortest paths from
+// where they are joined, a Dijkstra from every vertex at once.  from gets
+// the vertex each improved tree was extended from.
+func (sg *steinerGraph) extend(best []float64, from []int32, verts, local []int) {
+	done := make([]bool, len(verts))
+	for lv := range from {
+		from[lv] = -1
+	}
+
+	q := make(distQueue, 0, len(verts))
+	for lv, d := range best {
+		if !math.IsInf(d, 1) {
+			q = append(q, distItem{v: lv, d: d})
+		}
+	}
+	heap.Init(&q)
+
+	for q.Len() > 0 {
+		it := heap.Pop(&q).(distItem)
+		if done[it.v] || it.d > best[it.v] {
+			continue
+		}
+		done[it.v] = true
+
+		u := verts[it.v]
+		for _, a := range sg.adj[u] {
+			lt := local[a.to]
+			if lt < 0 {
+				continue
+			}
+			if d := it.d + a.w; d < best[lt] {
+				best[lt] = d
+				from[lt] = int32(it.v)
+				heap.Push(&q, distItem{v: lt, d: d})
+			}
+		}
+	}
+}
+
+// arc returns the arc from u to v, the cheapest edge between them
+func (sg *steinerGraph) arc(u, v int) steinerArc {
+	for _, a := range sg.adj[u] {
+		if a.to == v {
+			return a
+		}
+	}
+	panic(fmt.Sprintf("steiner: no arc %s -- %s", sg.names[u], sg.names[v]))
+}
+
+// kmb joins the terminals by a minimum spanning tree of the shortest paths
+// between them, Kou, Markowsky and Berman 1981.
+func (sg *steinerGraph) kmb(terms []int) (map[*Edge]bool, error) {
+	dists := make([][]float64, len(terms))
+	vias := make([][]*steinerArc, len(terms))
+	for i, t := range terms {
+		dists[i], vias[i] = sg.shortest(t)
+	}
+	return sg.kmbPaths(terms, dists, vias)
+}
+
+// kmbPaths is kmb with the shortest paths from each terminal found already
+func (sg *steinerGraph) kmbPaths(terms []int, dist [][]float64, via [][]*steinerArc) (map[*Edge]bool, error) {
+	k := len(terms)
+
+	// prim over the terminal distance graph
+	in := make([]bool, k)
+	key := make([]float64, k)
+	parent := make([]int, k)
+	for i := range key {
+		key[i] = math.Inf(1)
+		parent[i] = -1
+	}
+	key[0] = 0
+
+	edges := make(map[*Edge]bool)
+	for range terms {
+		u := -1
+		for i := 0; i < k; i++ {
+			if !in[i] && (u == -1 || key[i] < key[u]) {
+				u = i
+			}
+		}
+		if math.IsInf(key[u], 1) {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrNoPath, sg.names[terms[0]], sg.names[terms[u]])
+		}
+		in[u] = true
+		if parent[u] >= 0 {
+			addPath(edges, via[parent[u]], terms[u])
+		}
+
+		for i := 0; i < k; i++ {
+			if !in[i] && dist[u][terms[i]] < key[i] {
+				key[i] = dist[u][terms[i]]
+				parent[i] = u
+			}
+		}
+	}
+
+	return edges, nil
+}
+
+// tree reduces the edges to a minimum spanning tree and trims leaves that
+// are not terminals.
+func (sg *steinerGraph) tree(terms []int, edges map[*Edge]bool) *Tree {
+	list := make([]*Edge, 0, len(edges))
+	for _, e := range sg.g.Edges {
+		if edges[e] {
+			list = append(list, e)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return sg.cost[list[i]] < sg.cost[list[j]]
+	})
+
+	// kruskal
+	root := make(map[string]string)
+	var find func(string) string
+	find = func(v string) string {
+		if r, ok := root[v]; ok && r != v {
+			root[v] = find(r)
+			return root[v]
+		}
+		root[v] = v
+		return v
+	}
+
+	kept := make([]*Edge, 0, len(list))
+	for _, e := range list {
+		a, b := find(e.Vertices[0].Name), find(e.Vertices[1].Name)
+		if a == b {
+			continue
+		}
+		root[a] = b
+		kept = append(kept, e)
+	}
+
+	isTerm := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		isTerm[sg.names[t]] = true
+	}
+
+	for trimmed := true; trimmed; {
+		trimmed = false
+		degree := make(map[string]int)
+		for _, e := range kept {
+			degree[e.Vertices[0].Name]++
+			degree[e.Vertices[1].Name]++
+		}
+		next := kept[:0]
+		for _, e := range kept {
+			a, b := e.Vertices[0].Name, e.Vertices[1].Name
+			if (degree[a] == 1 && !isTerm[a]) || (degree[b] == 1 && !isTerm[b]) {
+				trimmed = true
+				continue
+			}
+			next = append(next, e)
+		}
+		kept = next
+	}
+
+	t := &Tree{Vertices: []*Vertex{}, Edges: []*Edge{}}
+	inTree := make(map[string]bool)
+	for _, e := range kept {
+		inTree[e.Vertices[0].Name] = true
+		inTree[e.Vertices[1].Name] = true
+	}
+	for _, name := range terms {
+		inTree[sg.names[name]] = true
+	}
+	for _, v := range sg.g.Vertices {
+		if inTree[v.Name] {
+			t.Vertices = append(t.Vertices, v)
+			inTree[v.Name] = false
+		}
+	}
+	keep := make(map[*Edge]bool, len(kept))
+	for _, e := range kept {
+		keep[e] = true
+	}
+	for _, e := range sg.g.Edges {
+		if keep[e] {
+			t.Edges = append(t.Edges, e)
+			t.Cost += sg.cost[e]
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,240 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSteinerTree(t *testing.T) {
+	G := testGraph(t, "test-star")
+	opts := &PathOptions{Weight: PropertyWeight("lat")}
+
+	tree, err := G.SteinerTree([]string{"a", "b", "c"}, opts)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 3.0, tree.Cost)
+	assert.ElementsMatch(t, []string{"as", "bs", "cs"}, bridgeIDs(tree.Edges))
+	assert.ElementsMatch(t, []string{"a", "b", "c", "s"}, tree.Names())
+	assert.Equal(t, []string{"a", "b", "c"}, tree.Leaves())
+
+	p, err := tree.Path("a", "c")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"a", "s", "c"}, p.Names())
+	assert.Equal(t, []string{"as", "cs"}, edgeIDs(p))
+
+	// the approximation joins the terminals directly
+	limit := SteinerExactLimit
+	SteinerExactLimit = 1
+	approx, err := G.SteinerTree([]string{"a", "b", "c"}, opts)
+	SteinerExactLimit = limit
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.InDelta(t, 3.6, approx.Cost, 1e-9)
+	assert.Equal(t, 2, len(approx.Edges))
+
+	// two terminals is the shortest path, one is a single vertex
+	tree, err = G.SteinerTree([]string{"a", "d"}, opts)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ac", "cd"}, bridgeIDs(tree.Edges))
+
+	tree, err = G.SteinerTree([]string{"d", "d"}, opts)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"d"}, tree.Names())
+	assert.Equal(t, 0, len(tree.Edges))
+}
+
+func TestSteinerTreeMultigraph(t *testing.T) {
+	G := testGraph(t, "test-multi")
+
+	tree, err := G.SteinerTree([]string{"a", "b", "d"}, &PathOptions{Weight: PropertyWeight("lat")})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ab1", "bd1"}, bridgeIDs(tree.Edges))
+	assert.Equal(t, 2.0, tree.Cost)
+
+	tree, err = G.SteinerTree([]string{"a", "b", "d"}, &PathOptions{
+		Weight:       PropertyWeight("lat"),
+		ExcludeEdges: []string{"ab1", "bd1"},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ab2", "bd2"}, bridgeIDs(tree.Edges))
+}
+
+func TestSteinerTreeErrors(t *testing.T) {
+	G := testGraph(t, "test-star")
+
+	_, err := G.SteinerTree(nil, nil)
+	assert.NotNil(t, err)
+
+	_, err = G.SteinerTree([]string{"a", "x"}, nil)
+	assert.True(t, errors.Is(err, ErrVertexNotFound))
+
+	_, err = G.SteinerTree([]string{"a", "d"}, &PathOptions{ExcludeVertices: []string{"d"}})
+	assert.True(t, errors.Is(err, ErrNoPath))
+
+	_, err = G.SteinerTree([]string{"a", "d"}, &PathOptions{ExcludeEdges: []string{"cd"}})
+	assert.True(t, errors.Is(err, ErrNoPath))
+}
+
+func TestSteinerTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+
+	for round := 0; round < 20; round++ {
+		G := &Graph{Name: "test-random"}
+		n := 12
+		for i := 1; i < n; i++ {
+			// a spanning path keeps the graph connected
+			_, err := G.AddEdge(&Vertex{Name: fmt.Sprintf("v%d", i-1)}, &Vertex{Name: fmt.Sprintf("v%d", i)},
+				map[string]string{"uuid": fmt.Sprintf("p%d", i), "lat": fmt.Sprintf("%d", 1+r.Intn(9))})
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+		for i := 0; i < 15; i++ {
+			a, b := r.Intn(n), r.Intn(n)
+			if a == b {
+				continue
+			}
+			_, err := G.AddEdge(&Vertex{Name: fmt.Sprintf("v%d", a)}, &Vertex{Name: fmt.Sprintf("v%d", b)},
+				map[string]string{"uuid": fmt.Sprintf("r%d", i), "lat": fmt.Sprintf("%d", 1+r.Intn(9))})
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		terms := make([]string, 0)
+		for _, i := range r.Perm(n)[:2+r.Intn(4)] {
+			terms = append(terms, fmt.Sprintf("v%d", i))
+		}
+
+		opts := &PathOptions{Weight: PropertyWeight("lat")}
+		exact, err := G.SteinerTree(terms, opts)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		limit := SteinerExactLimit
+		SteinerExactLimit = 0
+		approx, err := G.SteinerTree(terms, opts)
+		SteinerExactLimit = limit
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		assert.Equal(t, bruteSteiner(t, G, terms), exact.Cost, "round %d", round)
+		assert.LessOrEqual(t, exact.Cost, approx.Cost, "round %d", round)
+		assert.LessOrEqual(t, approx.Cost, 2*exact.Cost, "round %d", round)
+
+		// a tree has one edge less than vertices and reaches every terminal
+		for _, tr := range []*Tree{exact, approx} {
+			assert.Equal(t, len(tr.Vertices)-1, len(tr.Edges), "round %d", round)
+			for _, term := range terms[1:] {
+				_, err := tr.Path(terms[0], term)
+				assert.Nil(t, err, "round %d", round)
+			}
+		}
+	}
+}
+
+// bruteSteiner is the cheapest spanning tree over the terminals and every
+// subset of the other vertices.
+func bruteSteiner(t *testing.T, G *Graph, terms []string) float64 {
+	isTerm := make(map[string]bool)
+	for _, name := range terms {
+		isTerm[name] = true
+	}
+	others := make([]string, 0)
+	for _, v := range G.Vertices {
+		if !isTerm[v.Name] {
+			others = append(others, v.Name)
+		}
+	}
+
+	best := math.Inf(1)
+	for mask := 0; mask < 1<<len(others); mask++ {
+		in := make(map[string]bool)
+		for _, name := range terms {
+			in[name] = true
+		}
+		for i, name := range others {
+			if mask&(1<<i) != 0 {
+				in[name] = true
+			}
+		}
+
+		// prim over the induced subgraph
+		cost := 0.0
+		reached := map[string]bool{terms[0]: true}
+		for len(reached) < len(in) {
+			var next string
+			min := math.Inf(1)
+			for _, e := range G.Edges {
+				a, b := e.Vertices[0].Name, e.Vertices[1].Name
+				if !in[a] || !in[b] || reached[a] == reached[b] {
+					continue
+				}
+				w, err := e.floatProperty("lat")
+				if err != nil {
+					t.Fatalf("%v", err)
+				}
+				if w < min {
+					min = w
+					next = a
+					if reached[a] {
+						next = b
+					}
+				}
+			}
+			if math.IsInf(min, 1) {
+				break
+			}
+			reached[next] = true
+			cost += min
+		}
+		if len(reached) == len(in) && cost < best {
+			best = cost
+		}
+	}
+
+	return best
+}
+
+func benchmarkSteiner(b *testing.B, n, k int) {
+	G := benchGraph(b, n)
+	opts := &PathOptions{Weight: PropertyWeight("lat")}
+
+	terms := make([]string, 0, k)
+	for i := 0; i < k; i++ {
+		terms = append(terms, fmt.Sprintf("v%d", i*n/k))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := G.SteinerTree(terms, opts)
+		if err != nil {
+			b.Fatalf("%v", err)
+		}
+	}
+}
+
+func BenchmarkSteinerTree3000x2(b *testing.B)  { benchmarkSteiner(b, 3000, 2) }
+func BenchmarkSteinerTree10000x4(b *testing.B) { benchmarkSteiner(b, 10000, 4) }
+func BenchmarkSteinerTree50000x2(b *testing.B) { benchmarkSteiner(b, 50000, 2) }
+func BenchmarkSteinerTree50000x8(b *testing.B) { benchmarkSteiner(b, 50000, 8) }
@@ -0,0 +1,295 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+// What a graph event did, and to what
+const (
+	EventAdd    = "add"
+	EventUpdate = "update"
+	EventRemove = "remove"
+
+	EventVertex = "vertex"
+	EventEdge   = "edge"
+)
+
+// GraphEvent is the change of one vertex or edge.  Name is the vertex name
+// or the edge id, Vertices the ends of an edge.  Properties are all the
+// properties for an add or remove, and the changed ones for an update, Unset
+// the properties an update removed.
+type GraphEvent struct {
+	Action     string
+	Kind       string
+	Name       string
+	Vertices   []string
+	Properties map[string]string
+	Unset      []string
+}
+
+func (ev *GraphEvent) String() string {
+	if ev.Kind == EventEdge {
+		return fmt.Sprintf("%s edge %s %v", ev.Action, ev.Name, ev.Vertices)
+	}
+	return fmt.Sprintf("%s vertex %s", ev.Action, ev.Name)
+}
+
+// GraphChange is the events of one change to a stored graph, at the etcd
+// revision of the change.
+type GraphChange struct {
+	Revision int64
+	Events   []*GraphEvent
+}
+
+// GraphEvents flattens a diff into events in an order they can be applied
+// one at a time: edges are removed before their vertices, and added after.
+func GraphEvents(d *graph.GraphDiff) []*GraphEvent {
+	events := make([]*GraphEvent, 0)
+
+	for _, e := range d.RemovedEdges {
+		events = append(events, edgeEvent(EventRemove, e))
+	}
+	for _, v := range d.RemovedVertices {
+		events = append(events, vertexEvent(EventRemove, v))
+	}
+	for _, v := range d.AddedVertices {
+		events = append(events, vertexEvent(EventAdd, v))
+	}
+	for _, e := range d.AddedEdges {
+		events = append(events, edgeEvent(EventAdd, e))
+	}
+
+	for _, vd := range d.ModifiedVertices {
+		ev := &GraphEvent{Action: EventUpdate, Kind: EventVertex, Name: vd.Name}
+		changedProperties(ev, vd.Properties)
+		events = append(events, ev)
+	}
+	for _, ed := range d.ModifiedEdges {
+		ev := &GraphEvent{Action: EventUpdate, Kind: EventEdge, Name: ed.ID, Vertices: ed.Vertices}
+		changedProperties(ev, ed.Properties)
+		events = append(events, ev)
+	}
+
+	return events
+}
+
+func vertexEvent(action string, v *graph.Vertex) *GraphEvent {
+	return &GraphEvent{
+		Action:     action,
+		Kind:       EventVertex,
+		Name:       v.Name,
+		Properties: v.Properties,
+	}
+}
+
+func edgeEvent(action string, e *graph.Edge) *GraphEvent {
+	ends := make([]string, 0, len(e.Vertices))
+	for _, v := range e.Vertices {
+		ends = append(ends, v.Name)
+	}
+
+	return &GraphEvent{
+		Action:     action,
+		Kind:       EventEdge,
+		Name:       e.ID(),
+		Vertices:   ends,
+		Properties: e.Properties,
+	}
+}
+
+func changedProperties(ev *GraphEvent, changes []*graph.PropertyChange) {
+	ev.Properties = make(map[string]string)
+	for _, pc := range changes {
+		if pc.Removed {
+			ev.Unset = append(ev.Unset, pc.Key)
+			continue
+		}
+		ev.Properties[pc.Key] = pc.New
+	}
+}
+
+// Watch calls f with the changes to the stored graph until ctx is done, the
+// watch fails or f returns an error.  With initial set, f is first called
+// with the whole graph as added.  A view changes with its source, and a
+// deleted graph shows as everything removed.
+func (s *GraphStore) Watch(ctx context.Context, initial bool, f func(*GraphChange) error) error {
+	return stor.WithEtcd(func(c *clientv3.Client) error {
+		resp, err := c.Get(ctx, GraphPrefix+"/", clientv3.WithPrefix())
+		if err != nil {
+			return err
+		}
+		rev := resp.Header.Revision
+
+		st := newGraphState()
+		for _, kv := range resp.Kvs {
+			st.put(kv.Key, kv.Value)
+		}
+
+		prev, err := st.graph(s.Name, 0)
+		if err != nil {
+			return err
+		}
+
+		if initial {
+			events := GraphEvents(graph.Diff(nil, prev))
+			if len(events) > 0 {
+				err = f(&GraphChange{Revision: rev, Events: events})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// any stored graph can be the source of a view, each change is
+		// applied to the graphs as of the revision before it and the graph
+		// derived again when it follows the changed one
+		wch := c.Watch(ctx, GraphPrefix+"/", clientv3.WithPrefix(), clientv3.WithRev(rev+1))
+
+		log.Infof("watching graph %s from revision %d\n", s.Name, rev)
+
+		for wresp := range wch {
+			err := wresp.Err()
+			if err != nil {
+				return err
+			}
+
+			for _, ev := range wresp.Events {
+				name := st.name(ev.Kv.Key)
+				follows := st.follows(s.Name, name)
+				if ev.Type == clientv3.EventTypeDelete {
+					st.remove(name)
+				} else {
+					st.put(ev.Kv.Key, ev.Kv.Value)
+				}
+				// a view may have been pointed at the graph, or away from it
+				if !follows && !st.follows(s.Name, name) {
+					continue
+				}
+
+				g, err := st.graph(s.Name, 0)
+				if err != nil {
+					return err
+				}
+
+				events := GraphEvents(graph.Diff(prev, g))
+				prev = g
+				if len(events) == 0 {
+					continue
+				}
+
+				err = f(&GraphChange{Revision: ev.Kv.ModRevision, Events: events})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("graph watch closed")
+	})
+}
+
+// graphState is the stored graphs as of one revision, kept by a watch from
+// the values of its events.  A graph is decoded, or a view derived, when it
+// is first asked for after a change.
+type graphState struct {
+	stored  map[string]*StoredGraph
+	decoded map[string]*graph.Graph
+}
+
+func newGraphState() *graphState {
+	return &graphState{
+		stored:  make(map[string]*StoredGraph),
+		decoded: make(map[string]*graph.Graph),
+	}
+}
+
+// name returns the graph name of a stored graph key
+func (st *graphState) name(key []byte) string {
+	return strings.TrimPrefix(string(key), GraphPrefix+"/")
+}
+
+// put sets the stored graph of a key from its value
+func (st *graphState) put(key, value []byte) {
+	sg := &StoredGraph{}
+	stor.FromJSON(sg, value)
+	sg.Name = st.name(key)
+
+	st.remove(sg.Name)
+	st.stored[sg.Name] = sg
+}
+
+// remove drops a graph, and every view as one may be of it
+func (st *graphState) remove(name string) {
+	delete(st.stored, name)
+	delete(st.decoded, name)
+	for n, sg := range st.stored {
+		if sg.Kind == KindView {
+			delete(st.decoded, n)
+		}
+	}
+}
+
+// follows checks if the graph name is, or is the source of a view that
+// is, the graph watched
+func (st *graphState) follows(watched, name string) bool {
+	for depth := 0; depth < MaxViewDepth; depth++ {
+		if watched == name {
+			return true
+		}
+		sg, ok := st.stored[watched]
+		if !ok || sg.Kind != KindView {
+			return false
+		}
+		watched = sg.Source
+	}
+	return false
+}
+
+// graph returns the named graph, nil when there is none or it is a view of
+// a graph there is not.
+func (st *graphState) graph(name string, depth int) (*graph.Graph, error) {
+	if depth >= MaxViewDepth {
+		return nil, fmt.Errorf("view %s: more than %d views deep", name, MaxViewDepth)
+	}
+	if g, ok := st.decoded[name]; ok {
+		return g, nil
+	}
+	sg, ok := st.stored[name]
+	if !ok {
+		return nil, nil
+	}
+
+	var g *graph.Graph
+	var err error
+	if sg.Kind == KindView {
+		var source *graph.Graph
+		source, err = st.graph(sg.Source, depth+1)
+		if err != nil || source == nil {
+			return nil, err
+		}
+
+		g, err = ViewGraph(source, sg.VertexFilter, sg.EdgeFilter)
+		if err != nil {
+			return nil, fmt.Errorf("view %s: %w", name, err)
+		}
+		g.Name = name
+	} else {
+		g, err = graph.FromJson([]byte(sg.Graph))
+		if err != nil {
+			return nil, fmt.Errorf("graph %s: %w", name, err)
+		}
+	}
+
+	st.decoded[name] = g
+	return g, nil
+}
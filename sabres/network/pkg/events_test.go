@@ -0,0 +1,114 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/mergetb/tech/stor"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+func TestGraphEvents(t *testing.T) {
+	a := &graph.Graph{}
+	_, err := a.AddVertex("a", "", map[string]string{"cpu": "8"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = a.AddVertex("b", "", map[string]string{"cpu": "4", "vmx": "true"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = a.AddEdge(&graph.Vertex{Name: "a"}, &graph.Vertex{Name: "b"}, map[string]string{"uuid": "ab"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// everything is added to an empty graph, vertices before edges
+	events := GraphEvents(graph.Diff(nil, a))
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, EventAdd, events[0].Action)
+	assert.Equal(t, EventVertex, events[0].Kind)
+	assert.Equal(t, EventEdge, events[2].Kind)
+	assert.Equal(t, "ab", events[2].Name)
+	assert.Equal(t, []string{"a", "b"}, events[2].Vertices)
+
+	b, err := a.DeepCopy()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = b.UpdateVertexProperties("b", map[string]string{"cpu": "16"}, []string{"vmx"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = b.RemoveVertex("a")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// the edge goes before its vertex
+	events = GraphEvents(graph.Diff(a, b))
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, "remove edge ab [a b]", events[0].String())
+	assert.Equal(t, "remove vertex a", events[1].String())
+	assert.Equal(t, EventUpdate, events[2].Action)
+	assert.Equal(t, map[string]string{"cpu": "16"}, events[2].Properties)
+	assert.Equal(t, []string{"vmx"}, events[2].Unset)
+
+	assert.Equal(t, 0, len(GraphEvents(graph.Diff(b, b))))
+}
+
+func TestGraphState(t *testing.T) {
+	G := nativeGraph(t)
+	data, err := G.ToJson()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	key := func(name string) []byte { return []byte(GraphPrefix + "/" + name) }
+	value := func(sg *StoredGraph) []byte { return []byte(stor.ToJSON(sg)) }
+
+	st := newGraphState()
+	st.put(key("default"), value(&StoredGraph{Name: "default", Kind: KindLoaded, Graph: data}))
+	st.put(key("fast"), value(&StoredGraph{Name: "fast", Kind: KindView, Source: "default", EdgeFilter: "bw >= 1000"}))
+	st.put(key("other"), value(&StoredGraph{Name: "other", Kind: KindLoaded, Graph: data}))
+
+	assert.True(t, st.follows("fast", "default"))
+	assert.True(t, st.follows("fast", "fast"))
+	assert.False(t, st.follows("fast", "other"))
+	assert.False(t, st.follows("default", "fast"))
+
+	v, err := st.graph("fast", 0)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 3, len(v.Edges))
+
+	// the view follows the value its source was changed to
+	H, err := G.DeepCopy()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = H.UpdateEdgeProperties("bc", map[string]string{"bw": "100"}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	data, err = H.ToJson()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	st.put(key("default"), value(&StoredGraph{Name: "default", Kind: KindLoaded, Graph: data}))
+
+	w, err := st.graph("fast", 0)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	events := GraphEvents(graph.Diff(v, w))
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "remove edge bc [b c]", events[0].String())
+
+	// with the source gone the view is too
+	st.remove("default")
+	w, err = st.graph("fast", 0)
+	assert.Nil(t, err)
+	assert.Nil(t, w)
+}
@@ -0,0 +1,235 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+// A reservation is made in two phases.  It is first held, under a lease that
+// drops the hold when its ttl runs out, and then committed to the ledger once
+// the slice it is for has been stored.  A manager that stops between the two
+// leaves no capacity behind.
+
+var (
+	ErrNoHold = errors.New("no hold, it expired or was never placed")
+)
+
+var (
+	HoldPrefix = "/graph/hold"
+
+	// DefaultHoldTTL is how long a slice has to commit its reservation
+	DefaultHoldTTL = 60 * time.Second
+)
+
+// Hold is a reservation not yet committed, it lasts as long as its lease
+type Hold struct {
+	Graph       string
+	Reservation *Reservation
+	Lease       int64
+	Expires     time.Time
+	Version     int64
+}
+
+// Required functions for stor
+// Hold definitions
+func (x *Hold) Key() string {
+	return fmt.Sprintf("%s/%s/%s", HoldPrefix, x.Graph, x.Reservation.Name)
+}
+func (x *Hold) SetVersion(v int64) { x.Version = v }
+func (x *Hold) GetVersion() int64  { return x.Version }
+func (x *Hold) Value() interface{} { return x }
+
+// SortedHolds returns the holds in name order
+func (l *Ledger) SortedHolds() []*Hold {
+	out := make([]*Hold, 0, len(l.Holds))
+	for _, h := range l.Holds {
+		out = append(out, h)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Reservation.Name < out[j].Reservation.Name })
+	return out
+}
+
+// readHolds returns the holds against the ledger of a graph
+func readHolds(root string) (map[string]*Hold, error) {
+	holds := make(map[string]*Hold)
+	err := stor.WithEtcd(func(c *clientv3.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), stor.GetConfig().Timeout)
+		defer cancel()
+
+		resp, err := c.Get(ctx, fmt.Sprintf("%s/%s/", HoldPrefix, root), clientv3.WithPrefix())
+		if err != nil {
+			return err
+		}
+
+		for _, kv := range resp.Kvs {
+			h := &Hold{}
+			stor.FromJSON(h, kv.Value)
+			h.Version = kv.Version
+			holds[h.Reservation.Name] = h
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// writeLedger writes the ledger, with ops, if it has not changed since it
+// was read and cmps hold.  Every change to the reservations and holds of a
+// graph writes its ledger, so two of them can not both take the same
+// capacity.
+func writeLedger(l *Ledger, cmps []clientv3.Cmp, ops ...clientv3.Op) error {
+	return stor.WithEtcd(func(c *clientv3.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), stor.GetConfig().Timeout)
+		defer cancel()
+
+		cmps = append(cmps, clientv3.Compare(clientv3.Version(l.Key()), "=", l.Version))
+		ops = append(ops, clientv3.OpPut(l.Key(), stor.ToJSON(l)))
+		resp, err := c.Txn(ctx).
+			If(cmps...).
+			Then(ops...).
+			Commit()
+		if err != nil {
+			return err
+		}
+		if !resp.Succeeded {
+			return stor.TxnFailed("ledger has changed since read")
+		}
+
+		l.Version++
+		return nil
+	})
+}
+
+// revokeLease drops a lease no longer needed, it runs out by itself if this
+// fails
+func revokeLease(lease int64) {
+	if lease == 0 {
+		return
+	}
+
+	err := stor.WithEtcd(func(c *clientv3.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), stor.GetConfig().Timeout)
+		defer cancel()
+
+		_, err := c.Revoke(ctx, clientv3.LeaseID(lease))
+		return err
+	})
+	if err != nil {
+		log.Warnf("revoke lease %x: %v", lease, err)
+	}
+}
+
+// HoldReservation holds a reservation against the ledger of a graph for ttl,
+// when there is enough capacity left in g for it.  It is released when the
+// ttl runs out before CommitReservation.
+func HoldReservation(name string, g *graph.Graph, r *Reservation, ttl time.Duration) (*Hold, error) {
+	if r.Name == "" {
+		return nil, fmt.Errorf("reservation name not set")
+	}
+
+	var lease int64
+	err := stor.WithEtcd(func(c *clientv3.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), stor.GetConfig().Timeout)
+		defer cancel()
+
+		resp, err := c.Grant(ctx, int64(ttl.Seconds()))
+		if err != nil {
+			return err
+		}
+		lease = int64(resp.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lease for hold %s: %w", r.Name, err)
+	}
+
+	for try := 0; try < StoreRetries; try++ {
+		var l *Ledger
+		l, err = ReadLedger(name)
+		if err != nil {
+			break
+		}
+
+		err = l.check(g, r)
+		if err != nil {
+			break
+		}
+
+		r.Created = time.Now()
+		h := &Hold{
+			Graph:       l.Graph,
+			Reservation: r,
+			Lease:       lease,
+			Expires:     r.Created.Add(ttl),
+		}
+
+		err = writeLedger(l, nil, clientv3.OpPut(h.Key(), stor.ToJSON(h), clientv3.WithLease(clientv3.LeaseID(lease))))
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("ledger %s changed during hold, retrying: %v", l.Graph, err)
+				continue
+			}
+			break
+		}
+
+		return h, nil
+	}
+
+	revokeLease(lease)
+	if err == nil || stor.IsTxnFailed(err) {
+		return nil, fmt.Errorf("ledger %s: hold failed after %d tries: %w", name, StoreRetries, err)
+	}
+	return nil, err
+}
+
+// CommitReservation moves a held reservation into the ledger of a graph,
+// where it stays until it is released.  Committing a reservation already
+// committed does nothing.
+func CommitReservation(name, reservation string) (*Reservation, error) {
+	var err error
+	for try := 0; try < StoreRetries; try++ {
+		var l *Ledger
+		l, err = ReadLedger(name)
+		if err != nil {
+			return nil, err
+		}
+
+		h, ok := l.Holds[reservation]
+		if !ok {
+			if r, ok := l.Reservations[reservation]; ok {
+				return r, nil
+			}
+			return nil, fmt.Errorf("%w: %s", ErrNoHold, reservation)
+		}
+		l.Reservations[reservation] = h.Reservation
+
+		// the hold must still be there, its lease may run out after the read
+		err = writeLedger(l,
+			[]clientv3.Cmp{clientv3.Compare(clientv3.Version(h.Key()), "=", h.Version)},
+			clientv3.OpDelete(h.Key()),
+		)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("ledger %s changed during commit, retrying: %v", l.Graph, err)
+				continue
+			}
+			return nil, err
+		}
+
+		revokeLease(h.Lease)
+		return h.Reservation, nil
+	}
+
+	return nil, fmt.Errorf("ledger %s: commit failed after %d tries: %w", name, StoreRetries, err)
+}
@@ -0,0 +1,248 @@
+package pkg
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	inventory "pulwar.isi.edu/sabres/orchestrator/inventory/protocol"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+// TODO: A node with physical and virtual resources overlap, so some
+// work in future towards how phy resources are allocated as virt,
+// and then how reservation affects both
+
+// ResourceProperties returns the vertex properties of a node resource, for
+// now assume either phy or virt.
+func ResourceProperties(ri *inventory.ResourceItem) (map[string]string, error) {
+	attrs := make(map[string]interface{})
+	if ri.Phy != nil {
+		attrs["cpu"] = ri.Phy.Cores
+		attrs["mem"] = ri.Phy.Memory
+		attrs["disk"] = ri.Phy.Storage
+	} else {
+		if ri.Virt != nil {
+			attrs["cpu"] = ri.Virt.Cores
+			attrs["mem"] = ri.Virt.Memory
+			attrs["disk"] = ri.Virt.Storage
+		}
+	}
+
+	if ri.Flags != nil {
+		attrs["simd128"] = ri.Flags.Simd128
+		attrs["simd256"] = ri.Flags.Simd256
+		attrs["simd512"] = ri.Flags.Simd512
+		attrs["aesni"] = ri.Flags.Aesni
+		attrs["rdrand"] = ri.Flags.Rdrand
+		attrs["vmx"] = ri.Flags.Vmx
+	}
+
+	ownerAttrs(attrs, ri.Owner, ri.Trust)
+
+	return attrProperties(attrs)
+}
+
+// ownerAttrs adds the owner and trust of a resource, resources with neither
+// are left without them, which reads as untrusted.
+func ownerAttrs(attrs map[string]interface{}, owner string, trust int64) {
+	if owner == "" && trust == 0 {
+		return
+	}
+	attrs["owner"] = owner
+	attrs["trust"] = trust
+}
+
+// LinkProperties returns the edge properties of a link of a network
+// resource.
+func LinkProperties(ri *inventory.ResourceItem, link *inventory.Connection) (map[string]string, error) {
+	attrs := map[string]interface{}{
+		"bw":  link.Bandwidth,
+		"lat": link.Latency,
+		"jit": link.Jitter,
+	}
+	// a link without a length has none, rather than 0km
+	if link.Distance != 0 {
+		attrs["distance"] = graph.Value{Kind: graph.KindInt, Num: float64(link.Distance), Unit: graph.UnitKm}
+	}
+	if link.Owner != "" {
+		ownerAttrs(attrs, link.Owner, link.Trust)
+	} else {
+		ownerAttrs(attrs, ri.Owner, ri.Trust)
+	}
+
+	ma, err := attrProperties(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	ma["uuid"] = link.Uuid
+	if ri.Network != nil {
+		ma["name"] = ri.Network.Name
+	}
+	ma["selector"] = ri.Parent
+
+	return ma, nil
+}
+
+// attrProperties formats typed values as graph properties in the units of
+// the graph attribute schema.
+func attrProperties(attrs map[string]interface{}) (map[string]string, error) {
+	ma := make(map[string]string)
+	for k, v := range attrs {
+		err := graph.SetAttr(ma, k, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ma, nil
+}
+
+// ApplyResource adds a resource to the graph, or updates it if it is there
+// already: a node is a vertex, a network is an edge for each link.  prev is
+// the resource before the change, nil if it is new, links of prev that are
+// gone are removed.  Vertices at the ends of a link that are not in the
+// graph yet are added without properties, until their own resource is
+// applied.  Properties prev gave that ri no longer does are removed.
+func ApplyResource(g *graph.Graph, prev, ri *inventory.ResourceItem) error {
+	if ri == nil {
+		return fmt.Errorf("apply resource: nil resource")
+	}
+
+	if ri.Network == nil {
+		props, err := ResourceProperties(ri)
+		if err != nil {
+			return fmt.Errorf("resource %s: %w", ri.Uuid, err)
+		}
+
+		if _, ok := g.GetVertex(ri.Uuid); ok {
+			var unset []string
+			if prev != nil {
+				old, err := ResourceProperties(prev)
+				if err != nil {
+					return fmt.Errorf("resource %s: %w", prev.Uuid, err)
+				}
+				unset = droppedKeys(old, props)
+			}
+
+			log.Infof("Updating vertex: %s\n", ri.Uuid)
+			return g.UpdateVertexProperties(ri.Uuid, props, unset)
+		}
+
+		log.Infof("Adding vertex: %s\n", ri.Uuid)
+		_, err = g.AddVertex(ri.Uuid, "", props)
+		return err
+	}
+
+	prevLinks := make(map[string]*inventory.Connection)
+	if prev != nil && prev.Network != nil {
+		for _, link := range prev.Network.Adjlist {
+			prevLinks[link.Uuid] = link
+		}
+	}
+
+	links := make(map[string]bool)
+	for _, link := range ri.Network.Adjlist {
+		links[link.Uuid] = true
+
+		props, err := LinkProperties(ri, link)
+		if err != nil {
+			return fmt.Errorf("link %s: %w", link.Uuid, err)
+		}
+
+		var unset []string
+		if old, ok := prevLinks[link.Uuid]; ok {
+			oldProps, err := LinkProperties(prev, old)
+			if err != nil {
+				return fmt.Errorf("link %s: %w", link.Uuid, err)
+			}
+			unset = droppedKeys(oldProps, props)
+		}
+
+		err = applyLink(g, link, props, unset)
+		if err != nil {
+			return fmt.Errorf("link %s: %w", link.Uuid, err)
+		}
+	}
+
+	if prev != nil && prev.Network != nil {
+		for _, link := range prev.Network.Adjlist {
+			if links[link.Uuid] || len(g.GetEdgesByID(link.Uuid)) == 0 {
+				continue
+			}
+
+			err := g.RemoveEdge(link.Uuid)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// droppedKeys returns the keys of old that props does not have, in order
+func droppedKeys(old, props map[string]string) []string {
+	keys := make([]string, 0)
+	for k := range old {
+		if _, ok := props[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyLink adds the edge of a link, or updates it when an edge with the
+// link uuid joins the same vertices, removing the unset properties.  A link
+// that moved is added again.
+func applyLink(g *graph.Graph, link *inventory.Connection, props map[string]string, unset []string) error {
+	for _, e := range g.GetEdgesByID(link.Uuid) {
+		a, b := e.Vertices[0].Name, e.Vertices[1].Name
+		if (a == link.SrcResource && b == link.DstResource) || (a == link.DstResource && b == link.SrcResource) {
+			log.Infof("Updating edge: %s\n", link.Uuid)
+			return g.UpdateEdgeProperties(link.Uuid, props, unset)
+		}
+
+		err := g.RemoveEdge(link.Uuid)
+		if err != nil {
+			return err
+		}
+		break
+	}
+
+	log.Infof("Adding edge: %s\n", link.Uuid)
+	_, err := g.AddEdge(&graph.Vertex{Name: link.SrcResource}, &graph.Vertex{Name: link.DstResource}, props)
+	return err
+}
+
+// RemoveResource removes a deleted resource from the graph, the vertex and
+// its edges for a node, or the edges of its links for a network.  Parts that
+// are already gone are skipped.
+func RemoveResource(g *graph.Graph, ri *inventory.ResourceItem) error {
+	if ri == nil {
+		return fmt.Errorf("remove resource: nil resource")
+	}
+
+	if ri.Network == nil {
+		if _, ok := g.GetVertex(ri.Uuid); !ok {
+			return nil
+		}
+
+		_, err := g.RemoveVertex(ri.Uuid)
+		return err
+	}
+
+	for _, link := range ri.Network.Adjlist {
+		if len(g.GetEdgesByID(link.Uuid)) == 0 {
+			continue
+		}
+
+		err := g.RemoveEdge(link.Uuid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	inventory "pulwar.isi.edu/sabres/orchestrator/inventory/protocol"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+func testNode(name string, cores int64) *inventory.ResourceItem {
+	return &inventory.ResourceItem{
+		Uuid:   name,
+		Parent: "inv-" + name,
+		Phy:    &inventory.Physical{Cores: cores, Memory: 16, Storage: 100},
+	}
+}
+
+func testNetwork(links ...*inventory.Connection) *inventory.ResourceItem {
+	return &inventory.ResourceItem{
+		Uuid:    "net",
+		Parent:  "inv-net",
+		Network: &inventory.Network{Name: "testnet", Adjlist: links},
+	}
+}
+
+func testLink(id, src, dst string, bw int64) *inventory.Connection {
+	return &inventory.Connection{Uuid: id, SrcResource: src, DstResource: dst, Bandwidth: bw, Latency: 1}
+}
+
+func TestApplyResource(t *testing.T) {
+	G := &graph.Graph{}
+
+	err := ApplyResource(G, nil, testNode("a", 8))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	v, ok := G.GetVertex("a")
+	assert.True(t, ok)
+	assert.Equal(t, "8", v.Properties["cpu"])
+
+	// the ends of a link are added before their own resource
+	net := testNetwork(testLink("ab", "a", "b", 100), testLink("bc", "b", "c", 100))
+	err = ApplyResource(G, nil, net)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 3, len(G.Vertices))
+	assert.Equal(t, 2, len(G.Edges))
+	e := G.GetEdgesByID("ab")[0]
+	assert.Equal(t, "inv-net", e.Properties["selector"])
+	assert.Equal(t, "testnet", e.Properties["name"])
+
+	// applying again changes nothing
+	before, _ := G.Fingerprint()
+	err = ApplyResource(G, nil, net)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	after, _ := G.Fingerprint()
+	assert.Equal(t, before, after)
+
+	err = ApplyResource(G, nil, testNode("b", 4))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	v, _ = G.GetVertex("b")
+	assert.Equal(t, "4", v.Properties["cpu"])
+
+	err = ApplyResource(G, testNode("a", 8), testNode("a", 16))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	v, _ = G.GetVertex("a")
+	assert.Equal(t, "16", v.Properties["cpu"])
+
+	// a link is upgraded, one is dropped and one moves
+	next := testNetwork(testLink("ab", "a", "b", 1000), testLink("ca", "c", "a", 100))
+	err = ApplyResource(G, net, next)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, len(G.Edges))
+	assert.Equal(t, "1000", G.GetEdgesByID("ab")[0].Properties["bw"])
+	assert.Equal(t, 0, len(G.GetEdgesByID("bc")))
+	assert.Equal(t, 1, len(G.GetEdgesByID("ca")))
+
+	moved := testNetwork(testLink("ab", "a", "c", 1000), testLink("ca", "c", "a", 100))
+	err = ApplyResource(G, next, moved)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ab := G.GetEdgesByID("ab")
+	assert.Equal(t, 1, len(ab))
+	assert.ElementsMatch(t, []string{"a", "c"}, []string{ab[0].Vertices[0].Name, ab[0].Vertices[1].Name})
+}
+
+func TestOwnerProperties(t *testing.T) {
+	node := testNode("a", 8)
+	props, err := ResourceProperties(node)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, ok := props["owner"]
+	assert.False(t, ok)
+
+	node.Owner, node.Trust = "acme", 2
+	props, err = ResourceProperties(node)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "acme", props["owner"])
+	assert.Equal(t, "2", props["trust"])
+
+	// links have the owner of their network unless they have their own
+	own := testLink("bc", "b", "c", 100)
+	own.Owner = "other"
+	net := testNetwork(testLink("ab", "a", "b", 100), own)
+	net.Owner, net.Trust = "acme", 2
+
+	props, err = LinkProperties(net, net.Network.Adjlist[0])
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "acme", props["owner"])
+	assert.Equal(t, "2", props["trust"])
+
+	props, err = LinkProperties(net, own)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "other", props["owner"])
+	assert.Equal(t, "0", props["trust"])
+}
+
+func TestApplyTrustDowngrade(t *testing.T) {
+	G := &graph.Graph{}
+
+	trusted := testNode("a", 8)
+	trusted.Owner, trusted.Trust = "acme", 3
+	err := ApplyResource(G, nil, trusted)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	net := testNetwork(testLink("ab", "a", "b", 100))
+	net.Owner, net.Trust = "acme", 3
+	net.Network.Adjlist[0].Distance = 10
+	err = ApplyResource(G, nil, net)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// the owner is gone and the trust with it, so the vertex and edge are
+	// untrusted rather than keeping the old level
+	demoted := testNode("a", 8)
+	err = ApplyResource(G, trusted, demoted)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	v, _ := G.GetVertex("a")
+	_, ok := v.Properties["trust"]
+	assert.False(t, ok)
+	_, ok = v.Properties["owner"]
+	assert.False(t, ok)
+
+	next := testNetwork(testLink("ab", "a", "b", 100))
+	err = ApplyResource(G, net, next)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	e := G.GetEdgesByID("ab")[0]
+	for _, key := range []string{"owner", "trust", "distance"} {
+		_, ok := e.Properties[key]
+		assert.False(t, ok, key)
+	}
+	assert.Equal(t, "100", e.Properties["bw"])
+
+	untrusted, err := constraint.Untrusted(e.Properties)
+	assert.Nil(t, err)
+	assert.True(t, untrusted)
+}
+
+func TestInventoryDistance(t *testing.T) {
+	G := &graph.Graph{}
+	for _, name := range []string{"a", "b", "c"} {
+		err := ApplyResource(G, nil, testNode(name, 8))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	ab, bc, ac := testLink("ab", "a", "b", 100), testLink("bc", "b", "c", 100), testLink("ac", "a", "c", 100)
+	ab.Distance, bc.Distance, ac.Distance = 50, 50, 500
+	err := ApplyResource(G, nil, testNetwork(ab, bc, ac))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, "500", G.GetEdgesByID("ac")[0].Properties["distance"])
+
+	ends := &protocol.Constraint{Object: "cpu", Operator: ">=", Lvalue: "1", Vertices: []string{"a", "c"}}
+
+	// the direct link is the fewest hops but too long
+	sol, err := nativeSolve(t, G, ends, &protocol.Constraint{Object: "distance", Operator: "<=", Lvalue: "100 miles", Locale: "global"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+
+	sol, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "distance", Operator: "<", Lvalue: "100km"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+}
+
+func TestRemoveResource(t *testing.T) {
+	G := &graph.Graph{}
+	net := testNetwork(testLink("ab", "a", "b", 100), testLink("bc", "b", "c", 100))
+	for _, ri := range []*inventory.ResourceItem{testNode("a", 8), testNode("b", 8), testNode("c", 8), net} {
+		err := ApplyResource(G, nil, ri)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	err := ApplyInventoryEvent(G, &InventoryEvent{Key: "/resource/c", Delete: true, Prev: testNode("c", 8)})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, ok := G.GetVertex("c")
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(G.Edges), "edges of the vertex go with it")
+
+	// the remaining link of the network, and again when already gone
+	err = RemoveResource(G, net)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 0, len(G.Edges))
+	assert.Equal(t, 2, len(G.Vertices))
+
+	err = RemoveResource(G, net)
+	assert.Nil(t, err)
+	err = RemoveResource(G, testNode("c", 8))
+	assert.Nil(t, err)
+
+	err = ApplyInventoryEvent(G, &InventoryEvent{Key: "/resource/x", Delete: true})
+	assert.NotNil(t, err)
+}
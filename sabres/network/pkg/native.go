@@ -0,0 +1,361 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+var (
+	ErrUnsatisfiable = errors.New("constraints can not be satisfied")
+	// ErrNoSolution is a slice the native solver did not find, which does
+	// not mean there is none
+	ErrNoSolution = errors.New("no solution found")
+)
+
+// trustedHopWeight is the cost of a trusted edge when the tree is the one
+// with the fewest untrusted hops, less than any number of untrusted ones
+const trustedHopWeight = 1e-6
+
+// NativeSolver solves latency, bandwidth, cpu, memory, disk, distance,
+// owner, trust and untrusted-hops constraints without cbs.  The vertices of
+// the cpu, memory and disk constraints are the nodes the slice joins, with
+// the least cost tree through the edges and vertices the local constraints
+// allow.
+//
+// cpu, memory and disk constraints hold for their vertices, or every vertex
+// when none are given.  bandwidth constraints, and latency and distance constraints that
+// are not global, hold for every edge, or the edges given.  owner and trust
+// constraints hold for every vertex and every edge, or those given.  A
+// global latency, distance or untrusted-hops constraint holds for the path
+// between every two nodes of the slice, and the tree is the one with the
+// least total of it.  Only one of them can be global in a request, the tree
+// can not be the least of two metrics.  The least total is not the least
+// between every two nodes of more than two, so a tree that does not keep to
+// the constraint is ErrNoSolution then rather than ErrUnsatisfiable.
+type NativeSolver struct{}
+
+func (s *NativeSolver) Name() string {
+	return SolverNative
+}
+
+func (s *NativeSolver) Solve(ctx context.Context, g *graph.Graph, parsed []*constraint.Constraint) (string, error) {
+
+	// the vertices of cpu, memory and disk constraints are the nodes of the
+	// slice, as cbs takes them.  Those of owner and trust constraints only
+	// say where they hold.
+	terminals := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, c := range parsed {
+		if !c.Object.OnVertices() {
+			continue
+		}
+		for _, v := range c.Vertices {
+			if !seen[v] {
+				seen[v] = true
+				terminals = append(terminals, v)
+			}
+		}
+	}
+
+	if len(terminals) == 0 {
+		return "", fmt.Errorf("no cpu, memory or disk constraint names the vertices to join")
+	}
+
+	opts := &graph.PathOptions{Weight: graph.HopWeight}
+	var global []*constraint.Constraint
+	for _, c := range parsed {
+		switch {
+		case c.Object.OnVertices():
+			excluded, err := s.excludeVertices(g, c, seen)
+			if err != nil {
+				return "", err
+			}
+			opts.ExcludeVertices = append(opts.ExcludeVertices, excluded...)
+
+		// an owner or trust constraint given only vertices or only edges
+		// holds for just those
+		case c.Object.OnPath():
+			if len(c.Edges) == 0 {
+				excluded, err := s.excludeVertices(g, c, seen)
+				if err != nil {
+					return "", err
+				}
+				opts.ExcludeVertices = append(opts.ExcludeVertices, excluded...)
+			}
+			if len(c.Vertices) == 0 {
+				excluded, err := s.excludeEdges(g, c)
+				if err != nil {
+					return "", err
+				}
+				opts.ExcludeEdges = append(opts.ExcludeEdges, excluded...)
+			}
+
+		// a global bandwidth is the least bandwidth of the slice, which is
+		// the same as every edge having it
+		case c.Locale == constraint.Global && c.Object != constraint.Bandwidth:
+			global = append(global, c)
+
+		default:
+			excluded, err := s.excludeEdges(g, c)
+			if err != nil {
+				return "", err
+			}
+			opts.ExcludeEdges = append(opts.ExcludeEdges, excluded...)
+		}
+	}
+
+	for _, c := range global {
+		if c.Object != global[0].Object {
+			reason := fmt.Sprintf("the native solver minimizes one global metric, constraint %d already makes %s global",
+				global[0].Index, global[0].Object)
+			return "", constraint.Errors{{Index: c.Index, Field: "locale", Value: string(c.Locale), Reason: reason}}
+		}
+	}
+
+	// the tree has the least total of the global metric, edges without it
+	// can not be measured and are left out.  Untrusted hops are
+	// counted, a trusted edge costs little so the tree is no longer than it
+	// has to be.  An untrusted vertex the slice passes through costs half on
+	// each edge to it.
+	switch {
+	case len(global) == 0:
+	case global[0].Object == constraint.UntrustedHops:
+		transit := make(map[string]float64)
+		for _, v := range g.Vertices {
+			if seen[v.Name] {
+				continue
+			}
+			untrusted, err := constraint.Untrusted(v.Properties)
+			if err != nil {
+				return "", fmt.Errorf("vertex %s: %w", v.Name, err)
+			}
+			if untrusted {
+				transit[v.Name] = 0.5
+			}
+		}
+
+		opts.Weight = func(e *graph.Edge) (float64, error) {
+			untrusted, err := constraint.Untrusted(e.Properties)
+			if err != nil {
+				return 0, err
+			}
+			w := trustedHopWeight
+			if untrusted {
+				w = 1
+			}
+			return w + transit[e.Vertices[0].Name] + transit[e.Vertices[1].Name], nil
+		}
+	default:
+		key := global[0].Object.Key()
+		opts.Weight = graph.PropertyWeight(key)
+		for _, e := range g.Edges {
+			if _, ok := e.Properties[key]; !ok {
+				opts.ExcludeEdges = append(opts.ExcludeEdges, e.ID())
+			}
+		}
+	}
+
+	tree, err := g.SteinerTree(terminals, opts)
+	if errors.Is(err, graph.ErrNoPath) {
+		return "", fmt.Errorf("%w: %v", ErrUnsatisfiable, err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range global {
+		err = checkGlobal(tree, c)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.Marshal(treeSolution(tree))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// excludeVertices returns the vertices a vertex constraint rules out, it is an
+// error when one of the vertices the slice joins is ruled out.
+func (s *NativeSolver) excludeVertices(g *graph.Graph, c *constraint.Constraint, terminals map[string]bool) ([]string, error) {
+	names := c.Vertices
+	if len(names) == 0 {
+		for _, v := range g.Vertices {
+			names = append(names, v.Name)
+		}
+	}
+
+	excluded := make([]string, 0)
+	for _, name := range names {
+		v, ok := g.GetVertex(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", graph.ErrVertexNotFound, name)
+		}
+
+		ok, err := c.HoldsFor(v.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %s: %w", name, err)
+		}
+		if ok {
+			continue
+		}
+		if terminals[name] {
+			return nil, fmt.Errorf("%w: vertex %s does not have %s", ErrUnsatisfiable, name, c)
+		}
+		excluded = append(excluded, name)
+	}
+
+	return excluded, nil
+}
+
+// excludeEdges returns the ids of the edges a local constraint rules out
+func (s *NativeSolver) excludeEdges(g *graph.Graph, c *constraint.Constraint) ([]string, error) {
+	only := make(map[string]bool)
+	for _, id := range c.Edges {
+		only[id] = true
+	}
+
+	excluded := make([]string, 0)
+	for _, e := range g.Edges {
+		if len(only) > 0 && !only[e.ID()] {
+			continue
+		}
+
+		ok, err := c.HoldsFor(e.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("edge %s: %w", e.ID(), err)
+		}
+		if !ok {
+			excluded = append(excluded, e.ID())
+		}
+	}
+
+	return excluded, nil
+}
+
+// checkGlobal checks a global constraint for the path between every two
+// terminals of the tree.
+func checkGlobal(tree *graph.Tree, c *constraint.Constraint) error {
+	key := c.Object.Key()
+	for i, src := range tree.Terminals {
+		for _, dst := range tree.Terminals[i+1:] {
+			p, err := tree.Path(src, dst)
+			if err != nil {
+				return err
+			}
+
+			total := 0.0
+			if c.Object == constraint.UntrustedHops {
+				total, err = untrustedHops(p)
+				if err != nil {
+					return err
+				}
+			} else {
+				for _, e := range p.Edges {
+					v, err := graph.GetAttr(e.Properties, key)
+					if err != nil {
+						return fmt.Errorf("edge %s: %w", e.ID(), err)
+					}
+					total += v.Num
+				}
+			}
+
+			ok, err := c.Holds(graph.Value{Kind: graph.KindFloat, Num: total, Unit: c.Value.Unit})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%w: %s to %s has %s %g, want %s",
+					globalFailure(tree, c), src, dst, c.Object, total, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// globalFailure returns why a tree does not keep to a global constraint.
+// The tree of two terminals is the shortest path between them, no path is
+// shorter so an upper bound it does not keep to can not be kept to.
+func globalFailure(tree *graph.Tree, c *constraint.Constraint) error {
+	if len(tree.Terminals) == 2 && (c.Operator == constraint.Less || c.Operator == constraint.LessEqual) {
+		return ErrUnsatisfiable
+	}
+	return ErrNoSolution
+}
+
+// untrustedHops counts the untrusted edges of a path and the untrusted
+// vertices it passes through
+func untrustedHops(p *graph.Path) (float64, error) {
+	total := 0.0
+	for _, e := range p.Edges {
+		untrusted, err := constraint.Untrusted(e.Properties)
+		if err != nil {
+			return 0, fmt.Errorf("edge %s: %w", e.ID(), err)
+		}
+		if untrusted {
+			total++
+		}
+	}
+
+	for i := 1; i < len(p.Vertices)-1; i++ {
+		v := p.Vertices[i]
+		untrusted, err := constraint.Untrusted(v.Properties)
+		if err != nil {
+			return 0, fmt.Errorf("vertex %s: %w", v.Name, err)
+		}
+		if untrusted {
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// treeSolution returns the tree in the form cbs returns a slice: the nodes
+// are the terminals, the edges every edge of the tree.
+func treeSolution(tree *graph.Tree) *Solution {
+	sol := &Solution{
+		Nodes: make([]map[string]string, 0, len(tree.Terminals)),
+		Edges: make([]map[string]string, 0, len(tree.Edges)),
+	}
+
+	terminals := make(map[string]bool)
+	for _, name := range tree.Terminals {
+		terminals[name] = true
+	}
+
+	for _, v := range tree.Vertices {
+		if !terminals[v.Name] {
+			continue
+		}
+
+		node := map[string]string{"node": v.Name}
+		for k, val := range v.Properties {
+			node[k] = val
+		}
+		sol.Nodes = append(sol.Nodes, node)
+	}
+	sort.Slice(sol.Nodes, func(i, j int) bool {
+		return sol.Nodes[i]["node"] < sol.Nodes[j]["node"]
+	})
+
+	for _, e := range tree.Edges {
+		edge := map[string]string{"src": e.Vertices[0].Name, "dst": e.Vertices[1].Name}
+		for k, val := range e.Properties {
+			edge[k] = val
+		}
+		sol.Edges = append(sol.Edges, edge)
+	}
+
+	return sol
+}
@@ -0,0 +1,295 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+// nativeEdge is an edge of a solver test graph, its uuid the names of its
+// ends
+type nativeEdge struct {
+	a, b, bw, lat string
+}
+
+// nativeTestGraph builds a graph of edges with the cpus of its vertices
+func nativeTestGraph(t *testing.T, cpus map[string]string, edges []nativeEdge) *graph.Graph {
+	G := &graph.Graph{}
+	for _, e := range edges {
+		prop := map[string]string{"uuid": e.a + e.b, "lat": e.lat}
+		if e.bw != "" {
+			prop["bw"] = e.bw
+		}
+		_, err := G.AddEdge(&graph.Vertex{Name: e.a}, &graph.Vertex{Name: e.b}, prop)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	for _, v := range G.Vertices {
+		v.Properties = map[string]string{"cpu": cpus[v.Name]}
+	}
+
+	return G
+}
+
+// nativeGraph is a square a-b-c-d with a fast long way round through b and a
+// slow short cut a-c
+func nativeGraph(t *testing.T) *graph.Graph {
+	return nativeTestGraph(t, map[string]string{"a": "8", "b": "4", "c": "16", "d": "2"}, []nativeEdge{
+		{"a", "b", "10000", "1"},
+		{"b", "c", "10000", "1"},
+		{"a", "c", "100", "5"},
+		{"c", "d", "1000", "2"},
+	})
+}
+
+func nativeSolve(t *testing.T, G *graph.Graph, cons ...*protocol.Constraint) (*Solution, error) {
+	parsed, err := constraint.ParseAll(cons)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := (&NativeSolver{}).Solve(context.TODO(), G, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	sol := &Solution{}
+	err = json.Unmarshal([]byte(data), sol)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return sol, nil
+}
+
+func solutionEdges(sol *Solution) []string {
+	ids := make([]string, 0)
+	for _, e := range sol.Edges {
+		ids = append(ids, e["uuid"])
+	}
+	return ids
+}
+
+func TestNativeSolver(t *testing.T) {
+	G := nativeGraph(t)
+
+	// the fewest hops is the short cut
+	sol, err := nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: ">=", Lvalue: "4", Vertices: []string{"a", "c"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ac"}, solutionEdges(sol))
+	assert.Equal(t, 2, len(sol.Nodes))
+	assert.Equal(t, "a", sol.Nodes[0]["node"])
+
+	// the short cut is too slow for the bandwidth
+	sol, err = nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: ">=", Lvalue: "4", Vertices: []string{"a", "c"}},
+		&protocol.Constraint{Object: "bandwidth", Operator: ">=", Lvalue: "1Gbps"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+
+	// a global latency picks the fastest tree and checks every pair
+	sol, err = nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "d"}},
+		&protocol.Constraint{Object: "latency", Operator: "<=", Lvalue: "4ms", Locale: "global"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc", "cd"}, solutionEdges(sol))
+
+	_, err = nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "d"}},
+		&protocol.Constraint{Object: "latency", Operator: "<", Lvalue: "4ms", Locale: "global"})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+
+	// b is the only fast way round and does not have the cpu
+	_, err = nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: ">=", Lvalue: "8", Vertices: []string{"a", "c"}},
+		&protocol.Constraint{Object: "cpu", Operator: ">=", Lvalue: "8"},
+		&protocol.Constraint{Object: "bandwidth", Operator: ">", Lvalue: "100"})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+
+	// an endpoint without the cpu
+	_, err = nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: "=", Lvalue: "16", Vertices: []string{"a", "c"}})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+}
+
+func TestNativeSolverGlobals(t *testing.T) {
+	G := nativeGraph(t)
+	ends := &protocol.Constraint{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "d"}}
+
+	// the fastest tree need not be the one with the fewest untrusted hops
+	_, err := nativeSolve(t, G, ends,
+		&protocol.Constraint{Object: "latency", Operator: "<=", Lvalue: "4ms", Locale: "global"},
+		&protocol.Constraint{Object: "untrusted-hops", Operator: "<=", Lvalue: "3"})
+	assert.True(t, errors.Is(err, constraint.ErrInvalid), "%v", err)
+	errs := constraint.FromError(err)
+	if assert.Equal(t, 1, len(errs)) {
+		assert.Equal(t, 2, errs[0].Index)
+		assert.Equal(t, "locale", errs[0].Field)
+	}
+
+	// two bounds on the same metric are the same tree
+	sol, err := nativeSolve(t, G, ends,
+		&protocol.Constraint{Object: "latency", Operator: "<=", Lvalue: "4ms", Locale: "global"},
+		&protocol.Constraint{Object: "latency", Operator: ">", Lvalue: "1ms", Locale: "global"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc", "cd"}, solutionEdges(sol))
+}
+
+func TestNativeSolverPairs(t *testing.T) {
+	// a-b-c is the least latency in all, through h is the least between
+	// every two of them
+	G := nativeTestGraph(t, map[string]string{"a": "4", "b": "4", "c": "4", "h": "4"}, []nativeEdge{
+		{"a", "b", "", "4"},
+		{"b", "c", "", "4"},
+		{"a", "h", "", "3"},
+		{"b", "h", "", "3"},
+		{"c", "h", "", "3"},
+	})
+
+	_, err := nativeSolve(t, G,
+		&protocol.Constraint{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "b", "c"}},
+		&protocol.Constraint{Object: "latency", Operator: "<", Lvalue: "7ms", Locale: "global"})
+	assert.True(t, errors.Is(err, ErrNoSolution), "%v", err)
+	assert.False(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+}
+
+func TestNativeSolverTrust(t *testing.T) {
+	G := nativeGraph(t)
+
+	// acme runs the long way round, the short cut is run by nobody trusted
+	for name, trust := range map[string]string{"a": "2", "b": "1", "c": "2", "d": "3"} {
+		v, _ := G.GetVertex(name)
+		v.Properties["owner"] = "acme"
+		v.Properties["trust"] = trust
+	}
+	for _, id := range []string{"ab", "bc", "cd"} {
+		e := G.GetEdgesByID(id)[0]
+		e.Properties["owner"] = "acme"
+		e.Properties["trust"] = "1"
+	}
+	G.GetEdgesByID("ac")[0].Properties["owner"] = "shady"
+
+	ends := &protocol.Constraint{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "c"}}
+
+	sol, err := nativeSolve(t, G, ends, &protocol.Constraint{Object: "owner", Operator: "!=", Lvalue: "shady"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+
+	// the short cut is the one untrusted hop
+	sol, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "untrusted-hops", Operator: "<", Lvalue: "1"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+
+	sol, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "1"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+
+	// b and the edges through it are not trusted enough
+	_, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "2"})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+
+	// with only the short cut left it is one untrusted hop too many
+	_, err = nativeSolve(t, G, ends,
+		&protocol.Constraint{Object: "owner", Operator: "!=", Lvalue: "acme", Edges: []string{"ab"}},
+		&protocol.Constraint{Object: "untrusted-hops", Operator: "<=", Lvalue: "0"})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+
+	// a vertex an owner constraint is scoped to is not made part of the
+	// slice, only kept out of it when it fails
+	sol, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "owner", Operator: "!=", Lvalue: "shady", Vertices: []string{"d"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, 2, len(sol.Nodes))
+	assert.Equal(t, []string{"ac"}, solutionEdges(sol))
+
+	sol, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "2", Vertices: []string{"b"}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ac"}, solutionEdges(sol))
+
+	_, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "bandwidth", Operator: ">=", Lvalue: "1Gbps"}, &protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "2", Vertices: []string{"b"}})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+
+	// an endpoint owned by a domain to avoid
+	_, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "owner", Operator: "=", Lvalue: "shady"})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+}
+
+func TestNativeSolverUntrustedTransit(t *testing.T) {
+	G := nativeGraph(t)
+
+	// everything is trusted but b, the short cut is the way to a trusted c
+	for _, v := range G.Vertices {
+		v.Properties["trust"] = "1"
+	}
+	for _, e := range G.Edges {
+		e.Properties["trust"] = "1"
+	}
+	b, _ := G.GetVertex("b")
+	b.Properties["trust"] = "0"
+
+	ends := &protocol.Constraint{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"a", "c"}}
+	sol, err := nativeSolve(t, G, ends, &protocol.Constraint{Object: "untrusted-hops", Operator: "<=", Lvalue: "0"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ac"}, solutionEdges(sol))
+
+	// the short cut is too slow, the way round passes through b
+	fast := &protocol.Constraint{Object: "bandwidth", Operator: ">=", Lvalue: "1Gbps"}
+	_, err = nativeSolve(t, G, ends, fast, &protocol.Constraint{Object: "untrusted-hops", Operator: "<=", Lvalue: "0"})
+	assert.True(t, errors.Is(err, ErrUnsatisfiable), "%v", err)
+
+	sol, err = nativeSolve(t, G, ends, fast, &protocol.Constraint{Object: "untrusted-hops", Operator: "<=", Lvalue: "1"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.ElementsMatch(t, []string{"ab", "bc"}, solutionEdges(sol))
+
+	// an untrusted end of the path is not a hop
+	a, _ := G.GetVertex("a")
+	a.Properties["trust"] = "0"
+	sol, err = nativeSolve(t, G, ends, &protocol.Constraint{Object: "untrusted-hops", Operator: "<=", Lvalue: "0"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, []string{"ac"}, solutionEdges(sol))
+}
+
+func TestNativeSolverErrors(t *testing.T) {
+	G := nativeGraph(t)
+
+	for _, cc := range []*protocol.Constraint{
+		{Object: "colour", Operator: "=", Lvalue: "red", Vertices: []string{"a"}},
+		{Object: "cpu", Operator: "~", Lvalue: "4", Vertices: []string{"a"}},
+		{Object: "latency", Operator: "<", Lvalue: "10Mbps", Vertices: []string{"a"}},
+		{Object: "bandwidth", Operator: ">", Lvalue: "1Gbps"},
+		{Object: "cpu", Operator: ">", Lvalue: "1", Vertices: []string{"z"}},
+	} {
+		_, err := nativeSolve(t, G, cc)
+		assert.NotNil(t, err, "%v", cc)
+	}
+}
@@ -0,0 +1,486 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+var (
+	ErrReservationExists    = errors.New("reservation already exists")
+	ErrNoReservation        = errors.New("reservation not found")
+	ErrInsufficientCapacity = errors.New("insufficient capacity")
+)
+
+var (
+	LedgerPrefix = "/graph/ledger"
+)
+
+// Amounts are the resources taken from each vertex or edge, by vertex name
+// or edge id and then property, in the unit of the property.
+type Amounts map[string]map[string]float64
+
+func (a Amounts) add(name, key string, v float64) {
+	if a[name] == nil {
+		a[name] = make(map[string]float64)
+	}
+	a[name][key] += v
+}
+
+// Reservation is the resources a slice holds
+type Reservation struct {
+	Name     string
+	Vertices Amounts
+	Edges    Amounts
+	Created  time.Time
+	// Snapshot is the fingerprint of the graph snapshot it was made against
+	Snapshot string
+}
+
+// Ledger is the reservations against a graph.  Views share the ledger of
+// the graph they are a view of, so their slices take from the same
+// capacity.  Holds are kept apart under HoldPrefix, on a lease, and are read
+// in with the ledger.
+type Ledger struct {
+	Graph        string
+	Reservations map[string]*Reservation
+	Holds        map[string]*Hold `json:"-"`
+	Version      int64
+}
+
+// Required functions for stor
+// Ledger definitions
+func (x *Ledger) Key() string {
+	return fmt.Sprintf("%s/%s", LedgerPrefix, x.Graph)
+}
+func (x *Ledger) SetVersion(v int64) { x.Version = v }
+func (x *Ledger) GetVersion() int64  { return x.Version }
+func (x *Ledger) Value() interface{} { return x }
+
+// Totals returns the sum of the reservations, held ones included
+func (l *Ledger) Totals() (Amounts, Amounts) {
+	all := make([]*Reservation, 0, len(l.Reservations)+len(l.Holds))
+	for _, r := range l.Reservations {
+		all = append(all, r)
+	}
+	for _, h := range l.Holds {
+		all = append(all, h.Reservation)
+	}
+
+	vertices, edges := make(Amounts), make(Amounts)
+	for _, r := range all {
+		for name, res := range r.Vertices {
+			for key, v := range res {
+				vertices.add(name, key, v)
+			}
+		}
+		for id, res := range r.Edges {
+			for key, v := range res {
+				edges.add(id, key, v)
+			}
+		}
+	}
+	return vertices, edges
+}
+
+// Sorted returns the reservations in name order
+func (l *Ledger) Sorted() []*Reservation {
+	out := make([]*Reservation, 0, len(l.Reservations))
+	for _, r := range l.Reservations {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// LedgerGraph returns the graph whose ledger a graph uses, the source at
+// the bottom of a view.
+func LedgerGraph(name string) (string, error) {
+	if name == "" {
+		name = DefaultGraphName
+	}
+
+	for depth := 0; depth < MaxViewDepth; depth++ {
+		sg, err := Store(name).Info()
+		if err != nil {
+			return "", err
+		}
+		if sg.Kind != KindView {
+			return name, nil
+		}
+		name = sg.Source
+	}
+
+	return "", fmt.Errorf("view %s: more than %d views deep", name, MaxViewDepth)
+}
+
+// ReadLedger returns the ledger of a graph, empty if nothing is reserved
+func ReadLedger(name string) (*Ledger, error) {
+	root, err := LedgerGraph(name)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Ledger{Graph: root}
+	err = stor.ReadNew(l)
+	if err != nil {
+		return nil, err
+	}
+	if l.Reservations == nil {
+		l.Reservations = make(map[string]*Reservation)
+	}
+
+	// holds are read after the ledger, one placed since changed the ledger
+	// as well and a write against this version fails
+	l.Holds, err = readHolds(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// ResidualGraph returns a copy of g with the reservations of the ledger
+// taken from the capacity of its vertices and edges.  Properties that are
+// not set are left out, capacity never goes below 0.
+func ResidualGraph(g *graph.Graph, l *Ledger) (*graph.Graph, error) {
+	rg, err := g.DeepCopy()
+	if err != nil {
+		return nil, err
+	}
+
+	vertices, edges := l.Totals()
+
+	for _, v := range rg.Vertices {
+		err = debit(v.Properties, vertices[v.Name])
+		if err != nil {
+			return nil, fmt.Errorf("vertex %s: %w", v.Name, err)
+		}
+	}
+	for _, e := range rg.Edges {
+		err = debit(e.Properties, edges[e.ID()])
+		if err != nil {
+			return nil, fmt.Errorf("edge %s: %w", e.ID(), err)
+		}
+	}
+
+	return rg, nil
+}
+
+// debit takes reserved amounts from properties
+func debit(props map[string]string, reserved map[string]float64) error {
+	for key, amount := range reserved {
+		if _, ok := props[key]; !ok {
+			continue
+		}
+
+		v, err := graph.GetAttr(props, key)
+		if err != nil {
+			return err
+		}
+
+		v.Num -= amount
+		if v.Num < 0 {
+			log.Warnf("%s reserved beyond capacity by %g\n", key, -v.Num)
+			v.Num = 0
+		}
+		props[key] = v.String()
+	}
+	return nil
+}
+
+// SliceDemand returns the resources a solution takes from the graph.  A
+// constraint asking for at least an amount of cpu, mem or disk takes that
+// much from its vertices, every node of the solution when it names none.
+// One asking for at least an amount of bandwidth takes it from each edge of
+// the solution it holds for.  Where several constraints ask for the same
+// resource, the largest amount is taken.
+func SliceDemand(g *graph.Graph, cons []*constraint.Constraint, sol *Solution) (*Reservation, error) {
+	nodes := make([]string, 0, len(sol.Nodes))
+	for _, n := range sol.Nodes {
+		nodes = append(nodes, n["node"])
+	}
+
+	edges, err := solutionGraphEdges(g, sol)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reservation{Vertices: make(Amounts), Edges: make(Amounts)}
+	take := func(a Amounts, name, key string, v float64) {
+		if a[name] == nil {
+			a[name] = make(map[string]float64)
+		}
+		if v > a[name][key] {
+			a[name][key] = v
+		}
+	}
+
+	for _, c := range cons {
+		switch c.Operator {
+		case constraint.GreaterEqual, constraint.Greater, constraint.Equal:
+		default:
+			// an upper bound takes nothing
+			continue
+		}
+
+		key := c.Object.Key()
+		switch {
+		case c.Object.OnVertices():
+			names := c.Vertices
+			if len(names) == 0 {
+				names = nodes
+			}
+			for _, name := range names {
+				take(r.Vertices, name, key, c.Value.Num)
+			}
+
+		case c.Object == constraint.Bandwidth:
+			only := make(map[string]bool)
+			for _, id := range c.Edges {
+				only[id] = true
+			}
+			for _, e := range edges {
+				if len(only) > 0 && !only[e.ID()] {
+					continue
+				}
+				if c.Selector != "" && e.Properties["selector"] != c.Selector {
+					continue
+				}
+				take(r.Edges, e.ID(), key, c.Value.Num)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// solutionGraphEdges finds the graph edges of a solution, by uuid when the
+// solution has it and by the vertices joined otherwise.
+func solutionGraphEdges(g *graph.Graph, sol *Solution) ([]*graph.Edge, error) {
+	out := make([]*graph.Edge, 0, len(sol.Edges))
+	for _, se := range sol.Edges {
+		if id := se["uuid"]; id != "" {
+			found := g.GetEdgesByID(id)
+			if len(found) == 0 {
+				return nil, fmt.Errorf("solution edge %s not in graph %s", id, g.Name)
+			}
+			out = append(out, found[0])
+			continue
+		}
+
+		found, ok := g.FindEdge(&graph.Edge{Vertices: []*graph.Vertex{{Name: se["src"]}, {Name: se["dst"]}}})
+		if !ok {
+			return nil, fmt.Errorf("solution edge %s -- %s not in graph %s", se["src"], se["dst"], g.Name)
+		}
+		out = append(out, found[0])
+	}
+	return out, nil
+}
+
+// ParseSolution reads a solver response
+func ParseSolution(resp string) (*Solution, error) {
+	sol := &Solution{}
+	err := json.Unmarshal([]byte(resp), sol)
+	if err != nil {
+		return nil, fmt.Errorf("solution: %w", err)
+	}
+	return sol, nil
+}
+
+// Reserve adds a reservation to the ledger of a graph when there is enough
+// capacity left in g for it, g being the graph the ledger is for or a view
+// of it.
+func Reserve(name string, g *graph.Graph, r *Reservation) error {
+	if r.Name == "" {
+		return fmt.Errorf("reservation name not set")
+	}
+
+	var err error
+	for try := 0; try < StoreRetries; try++ {
+		var l *Ledger
+		l, err = ReadLedger(name)
+		if err != nil {
+			return err
+		}
+
+		err = l.check(g, r)
+		if err != nil {
+			return err
+		}
+
+		r.Created = time.Now()
+		l.Reservations[r.Name] = r
+
+		err = writeLedger(l, nil)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("ledger %s changed during reservation, retrying: %v", l.Graph, err)
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("ledger %s: reservation failed after %d tries: %w", name, StoreRetries, err)
+}
+
+// check checks that r is a new reservation and that there is capacity left
+// for it
+func (l *Ledger) check(g *graph.Graph, r *Reservation) error {
+	_, committed := l.Reservations[r.Name]
+	_, held := l.Holds[r.Name]
+	if committed || held {
+		return fmt.Errorf("%w: %s", ErrReservationExists, r.Name)
+	}
+
+	return checkCapacity(g, l, r)
+}
+
+// checkCapacity checks that the residual capacity of g covers r
+func checkCapacity(g *graph.Graph, l *Ledger, r *Reservation) error {
+	vertices, edges := l.Totals()
+
+	short := make([]string, 0)
+	check := func(kind, name string, props map[string]string, reserved, wanted map[string]float64) error {
+		for key, amount := range wanted {
+			v, err := graph.GetAttr(props, key)
+			if errors.Is(err, graph.ErrAttrMissing) {
+				short = append(short, fmt.Sprintf("%s %s has no %s", kind, name, key))
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			free := v.Num - reserved[key]
+			if amount > free {
+				short = append(short, fmt.Sprintf("%s %s %s: %g wanted, %g free", kind, name, key, amount, free))
+			}
+		}
+		return nil
+	}
+
+	for _, name := range sortedKeys(r.Vertices) {
+		v, ok := g.GetVertex(name)
+		if !ok {
+			return fmt.Errorf("%w: %s", graph.ErrVertexNotFound, name)
+		}
+		err := check("vertex", name, v.Properties, vertices[name], r.Vertices[name])
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range sortedKeys(r.Edges) {
+		found := g.GetEdgesByID(id)
+		if len(found) == 0 {
+			return fmt.Errorf("edge %s not in graph %s", id, g.Name)
+		}
+		err := check("edge", id, found[0].Properties, edges[id], r.Edges[id])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(short) > 0 {
+		return fmt.Errorf("%w: %s", ErrInsufficientCapacity, strings.Join(short, "; "))
+	}
+	return nil
+}
+
+func sortedKeys(a Amounts) []string {
+	keys := make([]string, 0, len(a))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Release removes a reservation from the ledger of a graph, which credits
+// its resources back.  A held reservation is dropped with its lease.
+func Release(name, reservation string) (*Reservation, error) {
+	var err error
+	for try := 0; try < StoreRetries; try++ {
+		var l *Ledger
+		l, err = ReadLedger(name)
+		if err != nil {
+			return nil, err
+		}
+
+		var r *Reservation
+		var ops []clientv3.Op
+		var lease int64
+		if h, ok := l.Holds[reservation]; ok {
+			r, lease = h.Reservation, h.Lease
+			ops = append(ops, clientv3.OpDelete(h.Key()))
+		} else {
+			r, ok = l.Reservations[reservation]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrNoReservation, reservation)
+			}
+			delete(l.Reservations, reservation)
+		}
+
+		// the ledger is kept when empty, its version orders every change to
+		// the reservations and holds
+		err = writeLedger(l, nil, ops...)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("ledger %s changed during release, retrying: %v", l.Graph, err)
+				continue
+			}
+			return nil, err
+		}
+
+		revokeLease(lease)
+		return r, nil
+	}
+
+	return nil, fmt.Errorf("ledger %s: release failed after %d tries: %w", name, StoreRetries, err)
+}
+
+// ReserveSolution reserves the resources of a solver response under name,
+// g is the graph with the capacity the solution was found in.  With a ttl
+// the reservation is held until it is committed or the ttl runs out.
+func ReserveSolution(graphName string, g *graph.Graph, cons []*constraint.Constraint, resp, name string, ttl time.Duration) (*Reservation, error) {
+	sol, err := ParseSolution(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := SliceDemand(g, cons, sol)
+	if err != nil {
+		return nil, err
+	}
+	r.Name = name
+
+	// the graph of a slice is kept while it holds the reservation, the
+	// snapshot is pruned after that
+	r.Snapshot, err = SaveSnapshot(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		_, err = HoldReservation(graphName, g, r, ttl)
+	} else {
+		err = Reserve(graphName, g, r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+func attrNum(t *testing.T, props map[string]string, key string) float64 {
+	v, err := graph.GetAttr(props, key)
+	if err != nil {
+		t.Fatalf("%s: %v", key, err)
+	}
+	return v.Num
+}
+
+func TestResidualGraph(t *testing.T) {
+	G := nativeGraph(t)
+
+	l := &Ledger{Graph: "default", Reservations: map[string]*Reservation{
+		"s1": {Name: "s1", Vertices: Amounts{"a": {"cpu": 2}}, Edges: Amounts{"ab": {"bw": 4000}}},
+		"s2": {Name: "s2", Vertices: Amounts{"a": {"cpu": 3, "mem": 8}, "d": {"cpu": 5}}},
+	}}
+
+	rg, err := ResidualGraph(G, l)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	a, _ := rg.GetVertex("a")
+	assert.Equal(t, 3.0, attrNum(t, a.Properties, "cpu"))
+	// a has no mem to take from
+	_, ok := a.Properties["mem"]
+	assert.False(t, ok)
+
+	// reserved beyond capacity stops at 0
+	d, _ := rg.GetVertex("d")
+	assert.Equal(t, 0.0, attrNum(t, d.Properties, "cpu"))
+
+	assert.Equal(t, 6000.0, attrNum(t, rg.GetEdgesByID("ab")[0].Properties, "bw"))
+	assert.Equal(t, 10000.0, attrNum(t, rg.GetEdgesByID("bc")[0].Properties, "bw"))
+
+	// the graph itself keeps its capacity
+	a, _ = G.GetVertex("a")
+	assert.Equal(t, 8.0, attrNum(t, a.Properties, "cpu"))
+}
+
+func TestSliceDemand(t *testing.T) {
+	G := nativeGraph(t)
+
+	cons, err := constraint.ParseAll([]*protocol.Constraint{
+		{Object: "cpu", Operator: ">=", Lvalue: "2"},
+		{Object: "cpu", Operator: ">=", Lvalue: "4", Vertices: []string{"c"}},
+		{Object: "cpu", Operator: "<", Lvalue: "100"},
+		{Object: "bandwidth", Operator: ">=", Lvalue: "1Gbps"},
+		{Object: "bandwidth", Operator: ">", Lvalue: "2Gbps", Edges: []string{"bc"}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	sol := &Solution{
+		Nodes: []map[string]string{{"node": "a"}, {"node": "c"}},
+		Edges: []map[string]string{
+			{"uuid": "ab", "src": "a", "dst": "b"},
+			{"src": "b", "dst": "c"},
+		},
+	}
+
+	r, err := SliceDemand(G, cons, sol)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assert.Equal(t, Amounts{"a": {"cpu": 2}, "c": {"cpu": 4}}, r.Vertices)
+	assert.Equal(t, Amounts{"ab": {"bw": 1000}, "bc": {"bw": 2000}}, r.Edges)
+
+	sol.Edges = append(sol.Edges, map[string]string{"src": "a", "dst": "d"})
+	_, err = SliceDemand(G, cons, sol)
+	assert.NotNil(t, err)
+}
+
+func TestCheckCapacity(t *testing.T) {
+	G := nativeGraph(t)
+
+	l := &Ledger{Graph: "default", Reservations: map[string]*Reservation{
+		"s1": {Name: "s1", Vertices: Amounts{"c": {"cpu": 12}}},
+	}}
+
+	err := checkCapacity(G, l, &Reservation{Vertices: Amounts{"c": {"cpu": 4}}, Edges: Amounts{"cd": {"bw": 1000}}})
+	assert.Nil(t, err)
+
+	err = checkCapacity(G, l, &Reservation{Vertices: Amounts{"c": {"cpu": 5}, "a": {"mem": 1}}})
+	assert.True(t, errors.Is(err, ErrInsufficientCapacity))
+	assert.Equal(t, "insufficient capacity: vertex a has no mem; vertex c cpu: 5 wanted, 4 free", err.Error())
+
+	err = checkCapacity(G, l, &Reservation{Edges: Amounts{"xy": {"bw": 1}}})
+	assert.NotNil(t, err)
+}
+
+func TestLedgerHolds(t *testing.T) {
+	G := nativeGraph(t)
+
+	l := &Ledger{Graph: "default",
+		Reservations: map[string]*Reservation{
+			"s1": {Name: "s1", Vertices: Amounts{"c": {"cpu": 8}}},
+		},
+		Holds: map[string]*Hold{
+			"s2": {Graph: "default", Reservation: &Reservation{Name: "s2", Vertices: Amounts{"c": {"cpu": 6}}}},
+		},
+	}
+	assert.Equal(t, "/graph/hold/default/s2", l.Holds["s2"].Key())
+
+	// held capacity is taken as well as committed
+	rg, err := ResidualGraph(G, l)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	c, _ := rg.GetVertex("c")
+	assert.Equal(t, 2.0, attrNum(t, c.Properties, "cpu"))
+
+	err = l.check(G, &Reservation{Name: "s3", Vertices: Amounts{"c": {"cpu": 3}}})
+	assert.True(t, errors.Is(err, ErrInsufficientCapacity))
+
+	err = l.check(G, &Reservation{Name: "s2", Vertices: Amounts{"c": {"cpu": 1}}})
+	assert.True(t, errors.Is(err, ErrReservationExists))
+
+	assert.Nil(t, l.check(G, &Reservation{Name: "s3", Vertices: Amounts{"c": {"cpu": 2}}}))
+}
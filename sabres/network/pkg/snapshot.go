@@ -0,0 +1,233 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+var (
+	SnapshotPrefix     = "/graph/snapshot"
+	SnapshotNamePrefix = "/graph/name"
+
+	// SnapshotGrace keeps a snapshot no one refers to this long, the
+	// reservation it was saved for is written after it
+	SnapshotGrace = 10 * time.Minute
+)
+
+// GraphSnapshot is a copy of the graph stored by its fingerprint, so a
+// snapshot of the same topology is only stored once.
+type GraphSnapshot struct {
+	Hash    string
+	Graph   string
+	Created time.Time
+	Version int64
+}
+
+// GraphSnapshotName points a name at the fingerprint of a snapshot
+type GraphSnapshotName struct {
+	Name    string
+	Hash    string
+	Version int64
+}
+
+// Required functions for stor
+// GraphSnapshot definitions
+func (x *GraphSnapshot) Key() string {
+	return fmt.Sprintf("%s/%s", SnapshotPrefix, x.Hash)
+}
+func (x *GraphSnapshot) SetVersion(v int64) { x.Version = v }
+func (x *GraphSnapshot) GetVersion() int64  { return x.Version }
+func (x *GraphSnapshot) Value() interface{} { return x }
+
+// GraphSnapshotName definitions
+func (x *GraphSnapshotName) Key() string {
+	return fmt.Sprintf("%s/%s", SnapshotNamePrefix, x.Name)
+}
+func (x *GraphSnapshotName) SetVersion(v int64) { x.Version = v }
+func (x *GraphSnapshotName) GetVersion() int64  { return x.Version }
+func (x *GraphSnapshotName) Value() interface{} { return x }
+
+// SaveSnapshot stores the graph in etcd by its fingerprint, and points each
+// of names at it.  The fingerprint is returned.  Saving a snapshot that is
+// already stored starts its grace again, so it is not pruned before the
+// reservation it is saved for is written.
+func SaveSnapshot(g *graph.Graph, names ...string) (string, error) {
+	hash, err := g.Fingerprint()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := g.ToJson()
+	if err != nil {
+		return "", err
+	}
+
+	snap := &GraphSnapshot{Hash: hash, Graph: data, Created: time.Now()}
+	err = checkSize("snapshot "+hash, snap)
+	if err != nil {
+		return "", err
+	}
+	objs := []stor.Object{snap}
+
+	for _, name := range names {
+		if name == "" {
+			return "", fmt.Errorf("snapshot name is empty")
+		}
+		objs = append(objs, &GraphSnapshotName{Name: name, Hash: hash})
+	}
+
+	err = stor.WriteObjects(objs, false)
+	if err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// ReadSnapshot returns a stored graph and its fingerprint, ref is either a
+// snapshot name or a fingerprint.
+func ReadSnapshot(ref string) (*graph.Graph, string, error) {
+	if ref == "" {
+		return nil, "", fmt.Errorf("snapshot name or fingerprint not given")
+	}
+
+	hash := ref
+	name := &GraphSnapshotName{Name: ref}
+	err := stor.Read(name)
+	if err == nil {
+		hash = name.Hash
+	} else if err != stor.ErrNotFound {
+		return nil, "", err
+	}
+
+	snap := &GraphSnapshot{Hash: hash}
+	err = stor.Read(snap)
+	if err == stor.ErrNotFound {
+		return nil, "", fmt.Errorf("snapshot %s: %w", ref, err)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	g, err := graph.FromJson([]byte(snap.Graph))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return g, hash, nil
+}
+
+// unreferencedSnapshots returns the fingerprints in hashes that no snapshot
+// name, reservation or hold refers to, in order
+func unreferencedSnapshots(hashes []string, names []*GraphSnapshotName, ledgers []*Ledger, holds []*Hold) []string {
+	refs := make(map[string]bool)
+	for _, n := range names {
+		refs[n.Hash] = true
+	}
+	for _, l := range ledgers {
+		for _, r := range l.Reservations {
+			refs[r.Snapshot] = true
+		}
+	}
+	for _, h := range holds {
+		refs[h.Reservation.Snapshot] = true
+	}
+
+	out := make([]string, 0)
+	for _, hash := range hashes {
+		if !refs[hash] {
+			out = append(out, hash)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// PruneSnapshots deletes the snapshots older than SnapshotGrace that no
+// snapshot name, reservation or hold refers to, and returns their
+// fingerprints.
+func PruneSnapshots() ([]string, error) {
+	var hashes []string
+	var names []*GraphSnapshotName
+	var ledgers []*Ledger
+	var holds []*Hold
+	err := stor.WithEtcd(func(c *clientv3.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), stor.GetConfig().Timeout)
+		defer cancel()
+
+		// the snapshots are listed first, one saved since for a reservation
+		// not seen below is still in its grace
+		resp, err := c.Get(ctx, SnapshotPrefix+"/", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			hashes = append(hashes, path.Base(string(kv.Key)))
+		}
+
+		for _, prefix := range []string{SnapshotNamePrefix, LedgerPrefix, HoldPrefix} {
+			resp, err = c.Get(ctx, prefix+"/", clientv3.WithPrefix())
+			if err != nil {
+				return err
+			}
+
+			for _, kv := range resp.Kvs {
+				switch {
+				case strings.HasPrefix(string(kv.Key), SnapshotNamePrefix):
+					n := &GraphSnapshotName{}
+					stor.FromJSON(n, kv.Value)
+					names = append(names, n)
+				case strings.HasPrefix(string(kv.Key), LedgerPrefix):
+					l := &Ledger{}
+					stor.FromJSON(l, kv.Value)
+					ledgers = append(ledgers, l)
+				default:
+					h := &Hold{}
+					stor.FromJSON(h, kv.Value)
+					holds = append(holds, h)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pruned := make([]string, 0)
+	for _, hash := range unreferencedSnapshots(hashes, names, ledgers, holds) {
+		snap := &GraphSnapshot{Hash: hash}
+		err = stor.Read(snap)
+		if err == stor.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return pruned, err
+		}
+		if time.Since(snap.Created) < SnapshotGrace {
+			continue
+		}
+
+		// a snapshot saved again since it was read is in a new grace
+		err = deleteAt(snap)
+		if stor.IsTxnFailed(err) {
+			continue
+		}
+		if err != nil {
+			return pruned, err
+		}
+		log.Infof("pruned snapshot %s", hash)
+		pruned = append(pruned, hash)
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,22 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnreferencedSnapshots(t *testing.T) {
+	hashes := []string{"named", "reserved", "held", "released", "unnamed"}
+
+	names := []*GraphSnapshotName{{Name: "before-upgrade", Hash: "named"}}
+	ledgers := []*Ledger{{Graph: "default", Reservations: map[string]*Reservation{
+		"s1": {Name: "s1", Snapshot: "reserved"},
+	}}}
+	holds := []*Hold{{Graph: "default", Reservation: &Reservation{Name: "s2", Snapshot: "held"}}}
+
+	assert.Equal(t, []string{"released", "unnamed"}, unreferencedSnapshots(hashes, names, ledgers, holds))
+
+	// once the hold runs out its snapshot goes as well
+	assert.Equal(t, []string{"held", "released", "unnamed"}, unreferencedSnapshots(hashes, names, ledgers, nil))
+}
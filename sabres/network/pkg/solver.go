@@ -0,0 +1,160 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+// ErrSolverUnavailable is a solver that could not be asked, the next one
+// may answer instead.  A solver that answered, even that there is no
+// solution, does not return it.
+var ErrSolverUnavailable = errors.New("solver unavailable")
+
+// Solver names
+const (
+	SolverCBS    = "cbs"
+	SolverNative = "native"
+)
+
+// Solver finds a slice of the graph meeting the parsed constraints, with
+// their values in the units of the graph.  The solution is the json of a
+// Solution, which is what cbs returns.
+type Solver interface {
+	Name() string
+	Solve(ctx context.Context, g *graph.Graph, cons []*constraint.Constraint) (string, error)
+}
+
+// Solution is the nodes and edges of a slice, each node has its name in
+// "node" and each edge its ends in "src" and "dst", with the properties of
+// the graph.
+type Solution struct {
+	Nodes []map[string]string `json:"nodes"`
+	Edges []map[string]string `json:"edges"`
+}
+
+// Solvers returns the solvers to try in order for a solve request.  No name
+// is cbs when its location was set, with the native solver to fall back on,
+// and the native solver when it was not.
+func Solvers(name string) ([]Solver, error) {
+	switch name {
+	case SolverNative:
+		return []Solver{&NativeSolver{}}, nil
+
+	case SolverCBS, "":
+		settings, err := ReadSolver()
+		if errors.Is(err, ErrNoSolver) && name == "" {
+			log.Infof("cbs location not set, using the native solver\n")
+			return []Solver{&NativeSolver{}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		solvers := []Solver{&CBSSolver{Endpoint: settings.Endpoint()}}
+		if name == "" {
+			solvers = append(solvers, &NativeSolver{})
+		}
+		return solvers, nil
+	}
+
+	return nil, fmt.Errorf("unknown solver %s, use %s or %s", name, SolverCBS, SolverNative)
+}
+
+// CBSSolver sends the graph and constraints to the cbs service over http
+type CBSSolver struct {
+	Endpoint string
+}
+
+// cbsRequest is the body cbs expects
+type cbsRequest struct {
+	Graph       *graph.Graph           `json:"graph"`
+	Constraints []*protocol.Constraint `json:"constraints"`
+}
+
+func (s *CBSSolver) Name() string {
+	return SolverCBS
+}
+
+func (s *CBSSolver) Solve(ctx context.Context, g *graph.Graph, parsed []*constraint.Constraint) (string, error) {
+	// cbs does not know owners and trust, its slice would not keep to them
+	cons := make([]*protocol.Constraint, 0, len(parsed))
+	for _, c := range parsed {
+		if c.Object.OnPath() || c.Object == constraint.UntrustedHops {
+			return "", fmt.Errorf("%w: constraint %d: cbs does not solve %s constraints", ErrSolverUnavailable, c.Index, c.Object)
+		}
+		cons = append(cons, c.Proto())
+	}
+
+	gg, err := g.DeepCopy()
+	if err != nil {
+		return "", err
+	}
+
+	for _, cc := range cons {
+		if cc.Object == "cpu" {
+			// for cbs, we need a mechanism to tell cbs that we specifically
+			// want to include this node in our graph
+			if len(cc.Vertices) < 1 {
+				return "", fmt.Errorf("constraint not formatted correctly")
+			}
+			v := cc.Vertices[0]
+
+			ok, vertex := gg.FindVertex(&graph.Vertex{Name: v})
+			if !ok {
+				return "", fmt.Errorf("couldnt find constraint vertex in graph")
+			}
+
+			if vertex.Properties == nil {
+				vertex.Properties = make(map[string]string)
+			}
+			vertex.Properties["endpoint"] = "yes"
+			log.Infof("Updating vertex info: %v", vertex)
+		}
+	}
+
+	// take constraints, create json
+	data, err := json.Marshal(&cbsRequest{Graph: gg, Constraints: cons})
+	if err != nil {
+		log.Errorf("erro marshaling: %v\n", err)
+		return "", err
+	}
+
+	log.Infof("Request to send to CBS: %s\n", data)
+
+	//POST request to CBSHost
+	endpoint := fmt.Sprintf("http://%s/cbs", s.Endpoint)
+	request, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(data))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	httpClient := &http.Client{}
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSolverUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSolverUnavailable, err)
+	}
+	log.Infof("resp from cbs: %s\n", string(body))
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cbs %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	return string(body), nil
+}
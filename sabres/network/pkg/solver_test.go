@@ -0,0 +1,64 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
+)
+
+func parseConstraints(t *testing.T, cons ...*protocol.Constraint) []*constraint.Constraint {
+	parsed, err := constraint.ParseAll(cons)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return parsed
+}
+
+func TestCBSSolverUnavailable(t *testing.T) {
+	G := nativeGraph(t)
+	cons := parseConstraints(t, &protocol.Constraint{Vertices: []string{"a", "c"}, Object: "Bandwidth", Operator: ">=", Lvalue: "1Gbps"})
+
+	// an answer that there is no solution is not a reason to ask another
+	var sent []*protocol.Constraint
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &struct {
+			Constraints []*protocol.Constraint `json:"constraints"`
+		}{}
+		err := json.NewDecoder(r.Body).Decode(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sent = req.Constraints
+		http.Error(w, "unsatisfiable", http.StatusUnprocessableEntity)
+	}))
+	s := &CBSSolver{Endpoint: strings.TrimPrefix(srv.URL, "http://")}
+
+	_, err := s.Solve(context.TODO(), G, cons)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrSolverUnavailable), "%v", err)
+	assert.Contains(t, err.Error(), "unsatisfiable")
+
+	// cbs is sent the constraint in the unit of the graph
+	if assert.Equal(t, 1, len(sent)) {
+		assert.Equal(t, "bandwidth", sent[0].Object)
+		assert.Equal(t, "1000", sent[0].Lvalue)
+	}
+
+	// nothing listening is
+	srv.Close()
+	_, err = s.Solve(context.TODO(), G, cons)
+	assert.True(t, errors.Is(err, ErrSolverUnavailable), "%v", err)
+
+	// and so are constraints cbs does not solve
+	_, err = s.Solve(context.TODO(), G, parseConstraints(t, &protocol.Constraint{Object: "trust", Operator: ">=", Lvalue: "1"}))
+	assert.True(t, errors.Is(err, ErrSolverUnavailable), "%v", err)
+}
@@ -0,0 +1,537 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+var (
+	ErrNoGraph     = errors.New("graph not defined. run create first.")
+	ErrNoSolver    = errors.New("CBSHost has not been set yet, use SetCBSLocation first")
+	ErrGraphExists = errors.New("graph already exists")
+	ErrView        = errors.New("graph is a view")
+	ErrGraphSize   = errors.New("graph too large to store")
+)
+
+// How a stored graph was made
+const (
+	// KindInventory is built from inventory and follows its changes
+	KindInventory = "inventory"
+	// KindLoaded is loaded from a file
+	KindLoaded = "loaded"
+	// KindCopy is a copy of another graph that is changed on its own
+	KindCopy = "copy"
+	// KindView is derived from another graph whenever it is read, so it
+	// follows the changes of its source and can not be changed itself
+	KindView = "view"
+)
+
+// MaxViewDepth limits how many views can be stacked on each other
+var MaxViewDepth = 8
+
+// MaxGraphBytes is the largest stored graph or snapshot, key and value as
+// written to etcd.  etcd refuses requests over 1.5MiB by default, this
+// leaves room for the rest of the request.
+var MaxGraphBytes = 1500 * 1024
+
+var (
+	GraphPrefix      = "/graph/current"
+	SolverPrefix     = "/graph/solver"
+	DefaultGraphName = "default"
+	DefaultSolver    = "cbs"
+
+	// StoreRetries is how many times an update is tried again when another
+	// network service changed the graph between the read and the write
+	StoreRetries = 5
+)
+
+// StoredGraph is a named graph of the network service.  A view stores its
+// source and filters instead of a graph.
+type StoredGraph struct {
+	Name         string
+	Kind         string
+	Source       string
+	VertexFilter string
+	EdgeFilter   string
+	Graph        string
+	Hash         string
+	Vertices     int
+	Edges        int
+	Version      int64
+}
+
+// SolverSettings is where the network service sends solve requests
+type SolverSettings struct {
+	Name    string
+	Host    string
+	Port    string
+	Version int64
+}
+
+// Required functions for stor
+// StoredGraph definitions
+func (x *StoredGraph) Key() string {
+	return fmt.Sprintf("%s/%s", GraphPrefix, x.Name)
+}
+func (x *StoredGraph) SetVersion(v int64) { x.Version = v }
+func (x *StoredGraph) GetVersion() int64  { return x.Version }
+func (x *StoredGraph) Value() interface{} { return x }
+
+// SolverSettings definitions
+func (x *SolverSettings) Key() string {
+	return fmt.Sprintf("%s/%s", SolverPrefix, x.Name)
+}
+func (x *SolverSettings) SetVersion(v int64) { x.Version = v }
+func (x *SolverSettings) GetVersion() int64  { return x.Version }
+func (x *SolverSettings) Value() interface{} { return x }
+
+// Endpoint returns the host:port of the solver
+func (x *SolverSettings) Endpoint() string {
+	return fmt.Sprintf("%s:%s", x.Host, x.Port)
+}
+
+// GraphStore keeps a named graph in etcd so it outlives the network service
+// and is shared by every replica.  The last graph read is cached by
+// fingerprint so it is only decoded, or a view derived, again when it
+// changed.
+type GraphStore struct {
+	Name string
+
+	mu     sync.Mutex
+	cached *graph.Graph
+	hash   string
+}
+
+var (
+	storesMu sync.Mutex
+	stores   = make(map[string]*GraphStore)
+)
+
+// Store returns the store of the named graph, the default graph when name
+// is empty.
+func Store(name string) *GraphStore {
+	if name == "" {
+		name = DefaultGraphName
+	}
+
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	s, ok := stores[name]
+	if !ok {
+		s = &GraphStore{Name: name}
+		stores[name] = s
+	}
+	return s
+}
+
+// ListGraphs returns every stored graph, without the encoded graphs, in
+// name order.
+func ListGraphs() ([]*StoredGraph, error) {
+	out := make([]*StoredGraph, 0)
+	err := stor.WithEtcd(func(c *clientv3.Client) error {
+		resp, err := c.Get(context.TODO(), GraphPrefix+"/", clientv3.WithPrefix())
+		if err != nil {
+			return err
+		}
+
+		for _, kv := range resp.Kvs {
+			sg := &StoredGraph{}
+			stor.FromJSON(sg, kv.Value)
+			sg.Version = kv.Version
+			sg.Graph = ""
+			out = append(out, sg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// read returns the stored graph object, which has version 0 when there is
+// no graph.
+func (s *GraphStore) read() (*StoredGraph, error) {
+	sg := &StoredGraph{Name: s.Name}
+	err := stor.ReadNew(sg)
+	if err != nil {
+		return nil, err
+	}
+	return sg, nil
+}
+
+// decode returns the graph of a stored graph object from the cache if the
+// fingerprint matches, for a view the fingerprint of its source.
+func (s *GraphStore) decode(sg *StoredGraph, depth int) (*graph.Graph, error) {
+	if sg.Version == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoGraph, s.Name)
+	}
+
+	if sg.Kind == KindView {
+		return s.view(sg, depth)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && s.hash == sg.Hash {
+		return s.cached, nil
+	}
+
+	g, err := graph.FromJson([]byte(sg.Graph))
+	if err != nil {
+		return nil, err
+	}
+
+	s.cached, s.hash = g, sg.Hash
+	return g, nil
+}
+
+// view derives a view from its source.
+func (s *GraphStore) view(sg *StoredGraph, depth int) (*graph.Graph, error) {
+	if depth >= MaxViewDepth {
+		return nil, fmt.Errorf("view %s: more than %d views deep", s.Name, MaxViewDepth)
+	}
+
+	source, err := Store(sg.Source).get(depth + 1)
+	if err != nil {
+		return nil, fmt.Errorf("view %s: %w", s.Name, err)
+	}
+
+	hash, err := source.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the filters are part of the cache key as the view can be redefined
+	key := fmt.Sprintf("%s|%s|%s", hash, sg.VertexFilter, sg.EdgeFilter)
+	if s.cached != nil && s.hash == key {
+		return s.cached, nil
+	}
+
+	g, err := ViewGraph(source, sg.VertexFilter, sg.EdgeFilter)
+	if err != nil {
+		return nil, fmt.Errorf("view %s: %w", s.Name, err)
+	}
+	g.Name = s.Name
+
+	s.cached, s.hash = g, key
+	return g, nil
+}
+
+// ViewGraph returns the subgraph of g matching the vertex and edge filter
+// expressions.
+func ViewGraph(g *graph.Graph, vertexExpr, edgeExpr string) (*graph.Graph, error) {
+	vf, err := graph.ParseFilter(vertexExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	ef, err := graph.ParseFilter(edgeExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Subgraph(vf, ef)
+}
+
+// Get returns the stored graph, or ErrNoGraph.  The graph is shared with
+// other callers and must not be changed, use Update.
+func (s *GraphStore) Get() (*graph.Graph, error) {
+	return s.get(0)
+}
+
+func (s *GraphStore) get(depth int) (*graph.Graph, error) {
+	sg, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decode(sg, depth)
+}
+
+// Info returns the stored graph object without the encoded graph
+func (s *GraphStore) Info() (*StoredGraph, error) {
+	sg, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if sg.Version == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoGraph, s.Name)
+	}
+
+	sg.Graph = ""
+	return sg, nil
+}
+
+// Update reads the stored graph, nil if there is none, and writes back the
+// graph f returns.  f is given its own copy to change.  When the graph was
+// changed by someone else before the write, f is run again on the new
+// graph.  A nil graph from f deletes the stored graph.  A new graph is a
+// loaded graph, views can not be updated.
+func (s *GraphStore) Update(f func(*graph.Graph) (*graph.Graph, error)) (*graph.Graph, error) {
+	return s.update(nil, f)
+}
+
+// Replace is Update that also sets how the graph was made, which turns a
+// view into a graph.
+func (s *GraphStore) Replace(kind, source string, f func(*graph.Graph) (*graph.Graph, error)) (*graph.Graph, error) {
+	return s.update(func(sg *StoredGraph) {
+		sg.Kind = kind
+		sg.Source = source
+		sg.VertexFilter = ""
+		sg.EdgeFilter = ""
+	}, f)
+}
+
+func (s *GraphStore) update(meta func(*StoredGraph), f func(*graph.Graph) (*graph.Graph, error)) (*graph.Graph, error) {
+	var err error
+	for try := 0; try < StoreRetries; try++ {
+		var sg *StoredGraph
+		sg, err = s.read()
+		if err != nil {
+			return nil, err
+		}
+
+		if meta != nil {
+			meta(sg)
+		} else if sg.Kind == KindView {
+			return nil, fmt.Errorf("%w: %s, change %s instead", ErrView, s.Name, sg.Source)
+		}
+		if sg.Kind == "" {
+			sg.Kind = KindLoaded
+		}
+
+		// a view being replaced has no graph of its own
+		var old *graph.Graph
+		if sg.Version > 0 && sg.Graph != "" {
+			old, err = graph.FromJson([]byte(sg.Graph))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var g *graph.Graph
+		g, err = f(old)
+		if err != nil {
+			return nil, err
+		}
+
+		if g == nil {
+			err = deleteAt(sg)
+			if err != nil {
+				if stor.IsTxnFailed(err) {
+					log.Warnf("graph %s changed during delete, retrying: %v", s.Name, err)
+					continue
+				}
+				return nil, err
+			}
+			s.setCache(nil, "")
+			return nil, nil
+		}
+
+		g.Name = s.Name
+		err = sg.setGraph(g)
+		if err != nil {
+			return nil, err
+		}
+
+		err = stor.Write(sg, true)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("graph %s changed during update, retrying: %v", s.Name, err)
+				continue
+			}
+			return nil, err
+		}
+
+		s.setCache(g, sg.Hash)
+		return g, nil
+	}
+
+	return nil, fmt.Errorf("graph %s: update failed after %d tries: %w", s.Name, StoreRetries, err)
+}
+
+// setGraph sets the graph of a stored graph, ErrGraphSize when it is then
+// too large to store
+func (sg *StoredGraph) setGraph(g *graph.Graph) error {
+	var err error
+	sg.Vertices, sg.Edges = len(g.Vertices), len(g.Edges)
+	sg.Hash, err = g.Fingerprint()
+	if err != nil {
+		return err
+	}
+	sg.Graph, err = g.ToJson()
+	if err != nil {
+		return err
+	}
+
+	return checkSize(sg.Name, sg)
+}
+
+// checkSize returns ErrGraphSize when obj, as stor writes it, is larger
+// than MaxGraphBytes.  The graph json is escaped in the object json, which
+// makes it larger than the graph.
+func checkSize(name string, obj stor.Object) error {
+	size := len(obj.Key()) + len(stor.ToJSON(obj))
+	if size > MaxGraphBytes {
+		return fmt.Errorf("%w: %s is %d bytes stored, at most %d fit in etcd, filter it with a view",
+			ErrGraphSize, name, size, MaxGraphBytes)
+	}
+	return nil
+}
+
+// CreateView stores a view of the source graph through the vertex and edge
+// filters, replacing an existing view but not a graph.
+func (s *GraphStore) CreateView(source, vertexExpr, edgeExpr string) (*StoredGraph, error) {
+	if source == "" {
+		source = DefaultGraphName
+	}
+	if source == s.Name {
+		return nil, fmt.Errorf("view %s of itself", s.Name)
+	}
+
+	// check the filters parse and the source is there
+	src, err := Store(source).Get()
+	if err != nil {
+		return nil, err
+	}
+	g, err := ViewGraph(src, vertexExpr, edgeExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	sg, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if sg.Version > 0 && sg.Kind != KindView {
+		return nil, fmt.Errorf("%w: %s is a %s graph", ErrGraphExists, s.Name, sg.Kind)
+	}
+
+	sg.Kind = KindView
+	sg.Source = source
+	sg.VertexFilter = vertexExpr
+	sg.EdgeFilter = edgeExpr
+	sg.Graph = ""
+	sg.Hash = ""
+	sg.Vertices, sg.Edges = len(g.Vertices), len(g.Edges)
+
+	err = stor.Write(sg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setCache(nil, "")
+	return sg, nil
+}
+
+// Delete removes the stored graph, graphs with views on them can not be
+// deleted before the views.  When the graph changes between the check and
+// the delete the check is done again.
+func (s *GraphStore) Delete() error {
+	var err error
+	for try := 0; try < StoreRetries; try++ {
+		var sg *StoredGraph
+		sg, err = s.read()
+		if err != nil {
+			return err
+		}
+
+		var all []*StoredGraph
+		all, err = ListGraphs()
+		if err != nil {
+			return err
+		}
+
+		views := make([]string, 0)
+		for _, v := range all {
+			if v.Kind == KindView && v.Source == s.Name {
+				views = append(views, v.Name)
+			}
+		}
+		if len(views) > 0 {
+			return fmt.Errorf("graph %s has views %v, delete them first", s.Name, views)
+		}
+
+		err = deleteAt(sg)
+		if err != nil {
+			if stor.IsTxnFailed(err) {
+				log.Warnf("graph %s changed during delete, retrying: %v", s.Name, err)
+				continue
+			}
+			return err
+		}
+
+		s.setCache(nil, "")
+		return nil
+	}
+
+	return fmt.Errorf("graph %s: delete failed after %d tries: %w", s.Name, StoreRetries, err)
+}
+
+// deleteAt deletes a stored object if it is still at the version it was
+// read at, a failed stor transaction when it is not.
+func deleteAt(obj stor.Object) error {
+	return stor.WithEtcd(func(c *clientv3.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), stor.GetConfig().Timeout)
+		defer cancel()
+
+		resp, err := c.Txn(ctx).
+			If(clientv3.Compare(clientv3.Version(obj.Key()), "=", obj.GetVersion())).
+			Then(clientv3.OpDelete(obj.Key())).
+			Commit()
+		if err != nil {
+			return err
+		}
+		if !resp.Succeeded {
+			return stor.TxnFailed(fmt.Sprintf("%s has changed since read", obj.Key()))
+		}
+		return nil
+	})
+}
+
+func (s *GraphStore) setCache(g *graph.Graph, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached, s.hash = g, hash
+}
+
+// ReadSolver returns the stored solver settings, or ErrNoSolver
+func ReadSolver() (*SolverSettings, error) {
+	ss := &SolverSettings{Name: DefaultSolver}
+	err := stor.Read(ss)
+	if err == stor.ErrNotFound {
+		return nil, ErrNoSolver
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+// WriteSolver stores the solver settings
+func WriteSolver(host, port string) (*SolverSettings, error) {
+	ss := &SolverSettings{Name: DefaultSolver, Host: host, Port: port}
+	err := stor.Write(ss, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
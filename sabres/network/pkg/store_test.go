@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/mergetb/tech/stor"
+)
+
+func TestGraphSize(t *testing.T) {
+	G := nativeGraph(t)
+	for _, v := range G.Vertices {
+		// quotes are escaped again in the stored object
+		v.Properties["note"] = `"quoted" "text"`
+	}
+
+	data, err := G.ToJson()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	max := MaxGraphBytes
+	defer func() { MaxGraphBytes = max }()
+
+	// the graph json fits, the stored graph around it does not
+	MaxGraphBytes = len(data)
+	sg := &StoredGraph{Name: "big", Kind: KindLoaded}
+	err = sg.setGraph(G)
+	assert.True(t, errors.Is(err, ErrGraphSize), "%v", err)
+	assert.Contains(t, err.Error(), "big")
+
+	stored := len(sg.Key()) + len(stor.ToJSON(sg))
+	assert.Greater(t, stored, len(data)+len(data)/10)
+
+	MaxGraphBytes = stored
+	assert.Nil(t, sg.setGraph(G))
+
+	MaxGraphBytes = stored - 1
+	assert.True(t, errors.Is(sg.setGraph(G), ErrGraphSize))
+
+	// a snapshot is measured the same way
+	snap := &GraphSnapshot{Hash: sg.Hash, Graph: data, Created: time.Now()}
+	MaxGraphBytes = len(data)
+	assert.True(t, errors.Is(checkSize("snapshot", snap), ErrGraphSize))
+	MaxGraphBytes = len(snap.Key()) + len(stor.ToJSON(snap))
+	assert.Nil(t, checkSize("snapshot", snap))
+}
@@ -0,0 +1,158 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	inventory "pulwar.isi.edu/sabres/orchestrator/inventory/protocol"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
+)
+
+// InventoryEvent is a change to an inventory or resource item in etcd.
+// Resource is the item after the change, nil for a delete, and Prev before
+// it, nil for a new item.
+type InventoryEvent struct {
+	Key      string
+	Revision int64
+	Delete   bool
+	Resource *inventory.ResourceItem
+	Prev     *inventory.ResourceItem
+}
+
+func (ev *InventoryEvent) String() string {
+	if ev.Delete {
+		return fmt.Sprintf("delete %s at %d", ev.Key, ev.Revision)
+	}
+	return fmt.Sprintf("put %s at %d", ev.Key, ev.Revision)
+}
+
+// ApplyInventoryEvent makes the change of an event to the graph.  An inventory
+// item and its resource item are written together, so the same resource is
+// usually applied twice, which leaves the graph as it was.
+func ApplyInventoryEvent(g *graph.Graph, ev *InventoryEvent) error {
+	if ev.Delete {
+		if ev.Prev == nil {
+			return fmt.Errorf("%s: deleted item unknown", ev)
+		}
+		return RemoveResource(g, ev.Prev)
+	}
+
+	if ev.Resource == nil {
+		return nil
+	}
+
+	return ApplyResource(g, ev.Prev, ev.Resource)
+}
+
+// InventoryRevision returns the etcd revision of the inventory, watching
+// from the revision after it sees every later change.
+func InventoryRevision() (int64, error) {
+	var rev int64
+	err := stor.WithEtcd(func(c *clientv3.Client) error {
+		resp, err := c.Get(context.TODO(), inventory.InvPrefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		if err != nil {
+			return err
+		}
+		rev = resp.Header.Revision
+		return nil
+	})
+
+	return rev, err
+}
+
+// WatchInventory calls f with each change to inventory (inventory.InvPrefix)
+// and resource (inventory.ResPrefix) items from revision rev on, in revision
+// order.  It returns when ctx is done, the watch fails, for example when rev
+// was compacted, or f returns an error.
+func WatchInventory(ctx context.Context, rev int64, f func(*InventoryEvent) error) error {
+	return stor.WithEtcd(func(c *clientv3.Client) error {
+		// one watch over both prefixes keeps the events in order, other
+		// keys between them are skipped
+		end := clientv3.GetPrefixRangeEnd(inventory.ResPrefix)
+		wch := c.Watch(ctx, inventory.InvPrefix,
+			clientv3.WithRange(end), clientv3.WithRev(rev), clientv3.WithPrevKV())
+
+		log.Infof("watching inventory from revision %d\n", rev)
+
+		for resp := range wch {
+			err := resp.Err()
+			if err != nil {
+				return err
+			}
+
+			for _, e := range resp.Events {
+				ev, err := inventoryEvent(e)
+				if err != nil {
+					return err
+				}
+				if ev == nil {
+					continue
+				}
+
+				err = f(ev)
+				if err != nil {
+					return fmt.Errorf("%s: %w", ev, err)
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("inventory watch closed")
+	})
+}
+
+// itemJSON reads the protobuf json stor writes inventory items as
+var itemJSON = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// inventoryEvent decodes an etcd event, nil if the key is not an inventory
+// or resource item.
+func inventoryEvent(e *clientv3.Event) (*InventoryEvent, error) {
+	key := string(e.Kv.Key)
+
+	var decode func([]byte) (*inventory.ResourceItem, error)
+	switch {
+	case strings.HasPrefix(key, inventory.InvPrefix+"/"):
+		decode = func(data []byte) (*inventory.ResourceItem, error) {
+			item := &inventory.InventoryItem{}
+			err := itemJSON.Unmarshal(data, item)
+			return item.Resource, err
+		}
+	case strings.HasPrefix(key, inventory.ResPrefix+"/"):
+		decode = func(data []byte) (*inventory.ResourceItem, error) {
+			item := &inventory.ResourceItem{}
+			err := itemJSON.Unmarshal(data, item)
+			return item, err
+		}
+	default:
+		return nil, nil
+	}
+
+	ev := &InventoryEvent{
+		Key:      key,
+		Revision: e.Kv.ModRevision,
+		Delete:   e.Type == clientv3.EventTypeDelete,
+	}
+
+	var err error
+	if e.PrevKv != nil {
+		ev.Prev, err = decode(e.PrevKv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if !ev.Delete {
+		ev.Resource, err = decode(e.Kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return ev, nil
+}
@@ -19,12 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Network_CreateGraph_FullMethodName     = "/netproto.Network/CreateGraph"
-	Network_DeleteGraph_FullMethodName     = "/netproto.Network/DeleteGraph"
-	Network_ShowGraph_FullMethodName       = "/netproto.Network/ShowGraph"
-	Network_GetGraph_FullMethodName        = "/netproto.Network/GetGraph"
-	Network_RequestSolution_FullMethodName = "/netproto.Network/RequestSolution"
-	Network_SetCBSLocation_FullMethodName  = "/netproto.Network/SetCBSLocation"
+	Network_CreateGraph_FullMethodName        = "/netproto.Network/CreateGraph"
+	Network_DeleteGraph_FullMethodName        = "/netproto.Network/DeleteGraph"
+	Network_ListGraphs_FullMethodName         = "/netproto.Network/ListGraphs"
+	Network_DeriveGraph_FullMethodName        = "/netproto.Network/DeriveGraph"
+	Network_LoadGraph_FullMethodName          = "/netproto.Network/LoadGraph"
+	Network_DiffGraph_FullMethodName          = "/netproto.Network/DiffGraph"
+	Network_ShowGraph_FullMethodName          = "/netproto.Network/ShowGraph"
+	Network_GetGraph_FullMethodName           = "/netproto.Network/GetGraph"
+	Network_RenderGraph_FullMethodName        = "/netproto.Network/RenderGraph"
+	Network_SnapshotGraph_FullMethodName      = "/netproto.Network/SnapshotGraph"
+	Network_WatchGraph_FullMethodName         = "/netproto.Network/WatchGraph"
+	Network_RemoveVertex_FullMethodName       = "/netproto.Network/RemoveVertex"
+	Network_RemoveEdge_FullMethodName         = "/netproto.Network/RemoveEdge"
+	Network_UpdateProperties_FullMethodName   = "/netproto.Network/UpdateProperties"
+	Network_AnalyzeGraph_FullMethodName       = "/netproto.Network/AnalyzeGraph"
+	Network_MaxFlow_FullMethodName            = "/netproto.Network/MaxFlow"
+	Network_RequestSolution_FullMethodName    = "/netproto.Network/RequestSolution"
+	Network_ListReservations_FullMethodName   = "/netproto.Network/ListReservations"
+	Network_ReleaseReservation_FullMethodName = "/netproto.Network/ReleaseReservation"
+	Network_CommitReservation_FullMethodName  = "/netproto.Network/CommitReservation"
+	Network_SetCBSLocation_FullMethodName     = "/netproto.Network/SetCBSLocation"
 )
 
 // NetworkClient is the client API for Network service.
@@ -33,9 +48,24 @@ const (
 type NetworkClient interface {
 	CreateGraph(ctx context.Context, in *CreateGraphRequest, opts ...grpc.CallOption) (*CreateGraphResponse, error)
 	DeleteGraph(ctx context.Context, in *DeleteGraphRequest, opts ...grpc.CallOption) (*DeleteGraphResponse, error)
+	ListGraphs(ctx context.Context, in *ListGraphsRequest, opts ...grpc.CallOption) (*ListGraphsResponse, error)
+	DeriveGraph(ctx context.Context, in *DeriveGraphRequest, opts ...grpc.CallOption) (*DeriveGraphResponse, error)
+	LoadGraph(ctx context.Context, in *LoadGraphRequest, opts ...grpc.CallOption) (*LoadGraphResponse, error)
+	DiffGraph(ctx context.Context, in *DiffGraphRequest, opts ...grpc.CallOption) (*DiffGraphResponse, error)
 	ShowGraph(ctx context.Context, in *ShowGraphRequest, opts ...grpc.CallOption) (*ShowGraphResponse, error)
 	GetGraph(ctx context.Context, in *GetGraphRequest, opts ...grpc.CallOption) (*GetGraphResponse, error)
+	RenderGraph(ctx context.Context, in *RenderGraphRequest, opts ...grpc.CallOption) (*RenderGraphResponse, error)
+	SnapshotGraph(ctx context.Context, in *SnapshotGraphRequest, opts ...grpc.CallOption) (*SnapshotGraphResponse, error)
+	WatchGraph(ctx context.Context, in *WatchGraphRequest, opts ...grpc.CallOption) (Network_WatchGraphClient, error)
+	RemoveVertex(ctx context.Context, in *RemoveVertexRequest, opts ...grpc.CallOption) (*RemoveVertexResponse, error)
+	RemoveEdge(ctx context.Context, in *RemoveEdgeRequest, opts ...grpc.CallOption) (*RemoveEdgeResponse, error)
+	UpdateProperties(ctx context.Context, in *UpdatePropertiesRequest, opts ...grpc.CallOption) (*UpdatePropertiesResponse, error)
+	AnalyzeGraph(ctx context.Context, in *AnalyzeGraphRequest, opts ...grpc.CallOption) (*AnalyzeGraphResponse, error)
+	MaxFlow(ctx context.Context, in *MaxFlowRequest, opts ...grpc.CallOption) (*MaxFlowResponse, error)
 	RequestSolution(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error)
+	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error)
 	SetCBSLocation(ctx context.Context, in *SetCBSRequest, opts ...grpc.CallOption) (*SetCBSResponse, error)
 }
 
@@ -65,6 +95,42 @@ func (c *networkClient) DeleteGraph(ctx context.Context, in *DeleteGraphRequest,
 	return out, nil
 }
 
+func (c *networkClient) ListGraphs(ctx context.Context, in *ListGraphsRequest, opts ...grpc.CallOption) (*ListGraphsResponse, error) {
+	out := new(ListGraphsResponse)
+	err := c.cc.Invoke(ctx, Network_ListGraphs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeriveGraph(ctx context.Context, in *DeriveGraphRequest, opts ...grpc.CallOption) (*DeriveGraphResponse, error) {
+	out := new(DeriveGraphResponse)
+	err := c.cc.Invoke(ctx, Network_DeriveGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) LoadGraph(ctx context.Context, in *LoadGraphRequest, opts ...grpc.CallOption) (*LoadGraphResponse, error) {
+	out := new(LoadGraphResponse)
+	err := c.cc.Invoke(ctx, Network_LoadGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DiffGraph(ctx context.Context, in *DiffGraphRequest, opts ...grpc.CallOption) (*DiffGraphResponse, error) {
+	out := new(DiffGraphResponse)
+	err := c.cc.Invoke(ctx, Network_DiffGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkClient) ShowGraph(ctx context.Context, in *ShowGraphRequest, opts ...grpc.CallOption) (*ShowGraphResponse, error) {
 	out := new(ShowGraphResponse)
 	err := c.cc.Invoke(ctx, Network_ShowGraph_FullMethodName, in, out, opts...)
@@ -83,6 +149,101 @@ func (c *networkClient) GetGraph(ctx context.Context, in *GetGraphRequest, opts
 	return out, nil
 }
 
+func (c *networkClient) RenderGraph(ctx context.Context, in *RenderGraphRequest, opts ...grpc.CallOption) (*RenderGraphResponse, error) {
+	out := new(RenderGraphResponse)
+	err := c.cc.Invoke(ctx, Network_RenderGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) SnapshotGraph(ctx context.Context, in *SnapshotGraphRequest, opts ...grpc.CallOption) (*SnapshotGraphResponse, error) {
+	out := new(SnapshotGraphResponse)
+	err := c.cc.Invoke(ctx, Network_SnapshotGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) WatchGraph(ctx context.Context, in *WatchGraphRequest, opts ...grpc.CallOption) (Network_WatchGraphClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Network_ServiceDesc.Streams[0], Network_WatchGraph_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkWatchGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Network_WatchGraphClient interface {
+	Recv() (*WatchGraphResponse, error)
+	grpc.ClientStream
+}
+
+type networkWatchGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkWatchGraphClient) Recv() (*WatchGraphResponse, error) {
+	m := new(WatchGraphResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkClient) RemoveVertex(ctx context.Context, in *RemoveVertexRequest, opts ...grpc.CallOption) (*RemoveVertexResponse, error) {
+	out := new(RemoveVertexResponse)
+	err := c.cc.Invoke(ctx, Network_RemoveVertex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) RemoveEdge(ctx context.Context, in *RemoveEdgeRequest, opts ...grpc.CallOption) (*RemoveEdgeResponse, error) {
+	out := new(RemoveEdgeResponse)
+	err := c.cc.Invoke(ctx, Network_RemoveEdge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) UpdateProperties(ctx context.Context, in *UpdatePropertiesRequest, opts ...grpc.CallOption) (*UpdatePropertiesResponse, error) {
+	out := new(UpdatePropertiesResponse)
+	err := c.cc.Invoke(ctx, Network_UpdateProperties_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) AnalyzeGraph(ctx context.Context, in *AnalyzeGraphRequest, opts ...grpc.CallOption) (*AnalyzeGraphResponse, error) {
+	out := new(AnalyzeGraphResponse)
+	err := c.cc.Invoke(ctx, Network_AnalyzeGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) MaxFlow(ctx context.Context, in *MaxFlowRequest, opts ...grpc.CallOption) (*MaxFlowResponse, error) {
+	out := new(MaxFlowResponse)
+	err := c.cc.Invoke(ctx, Network_MaxFlow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkClient) RequestSolution(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error) {
 	out := new(SolveResponse)
 	err := c.cc.Invoke(ctx, Network_RequestSolution_FullMethodName, in, out, opts...)
@@ -92,6 +253,33 @@ func (c *networkClient) RequestSolution(ctx context.Context, in *SolveRequest, o
 	return out, nil
 }
 
+func (c *networkClient) ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, Network_ListReservations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, Network_ReleaseReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error) {
+	out := new(CommitReservationResponse)
+	err := c.cc.Invoke(ctx, Network_CommitReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkClient) SetCBSLocation(ctx context.Context, in *SetCBSRequest, opts ...grpc.CallOption) (*SetCBSResponse, error) {
 	out := new(SetCBSResponse)
 	err := c.cc.Invoke(ctx, Network_SetCBSLocation_FullMethodName, in, out, opts...)
@@ -107,9 +295,24 @@ func (c *networkClient) SetCBSLocation(ctx context.Context, in *SetCBSRequest, o
 type NetworkServer interface {
 	CreateGraph(context.Context, *CreateGraphRequest) (*CreateGraphResponse, error)
 	DeleteGraph(context.Context, *DeleteGraphRequest) (*DeleteGraphResponse, error)
+	ListGraphs(context.Context, *ListGraphsRequest) (*ListGraphsResponse, error)
+	DeriveGraph(context.Context, *DeriveGraphRequest) (*DeriveGraphResponse, error)
+	LoadGraph(context.Context, *LoadGraphRequest) (*LoadGraphResponse, error)
+	DiffGraph(context.Context, *DiffGraphRequest) (*DiffGraphResponse, error)
 	ShowGraph(context.Context, *ShowGraphRequest) (*ShowGraphResponse, error)
 	GetGraph(context.Context, *GetGraphRequest) (*GetGraphResponse, error)
+	RenderGraph(context.Context, *RenderGraphRequest) (*RenderGraphResponse, error)
+	SnapshotGraph(context.Context, *SnapshotGraphRequest) (*SnapshotGraphResponse, error)
+	WatchGraph(*WatchGraphRequest, Network_WatchGraphServer) error
+	RemoveVertex(context.Context, *RemoveVertexRequest) (*RemoveVertexResponse, error)
+	RemoveEdge(context.Context, *RemoveEdgeRequest) (*RemoveEdgeResponse, error)
+	UpdateProperties(context.Context, *UpdatePropertiesRequest) (*UpdatePropertiesResponse, error)
+	AnalyzeGraph(context.Context, *AnalyzeGraphRequest) (*AnalyzeGraphResponse, error)
+	MaxFlow(context.Context, *MaxFlowRequest) (*MaxFlowResponse, error)
 	RequestSolution(context.Context, *SolveRequest) (*SolveResponse, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error)
 	SetCBSLocation(context.Context, *SetCBSRequest) (*SetCBSResponse, error)
 	mustEmbedUnimplementedNetworkServer()
 }
@@ -124,15 +327,60 @@ func (UnimplementedNetworkServer) CreateGraph(context.Context, *CreateGraphReque
 func (UnimplementedNetworkServer) DeleteGraph(context.Context, *DeleteGraphRequest) (*DeleteGraphResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGraph not implemented")
 }
+func (UnimplementedNetworkServer) ListGraphs(context.Context, *ListGraphsRequest) (*ListGraphsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGraphs not implemented")
+}
+func (UnimplementedNetworkServer) DeriveGraph(context.Context, *DeriveGraphRequest) (*DeriveGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeriveGraph not implemented")
+}
+func (UnimplementedNetworkServer) LoadGraph(context.Context, *LoadGraphRequest) (*LoadGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadGraph not implemented")
+}
+func (UnimplementedNetworkServer) DiffGraph(context.Context, *DiffGraphRequest) (*DiffGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffGraph not implemented")
+}
 func (UnimplementedNetworkServer) ShowGraph(context.Context, *ShowGraphRequest) (*ShowGraphResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShowGraph not implemented")
 }
 func (UnimplementedNetworkServer) GetGraph(context.Context, *GetGraphRequest) (*GetGraphResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetGraph not implemented")
 }
+func (UnimplementedNetworkServer) RenderGraph(context.Context, *RenderGraphRequest) (*RenderGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderGraph not implemented")
+}
+func (UnimplementedNetworkServer) SnapshotGraph(context.Context, *SnapshotGraphRequest) (*SnapshotGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotGraph not implemented")
+}
+func (UnimplementedNetworkServer) WatchGraph(*WatchGraphRequest, Network_WatchGraphServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGraph not implemented")
+}
+func (UnimplementedNetworkServer) RemoveVertex(context.Context, *RemoveVertexRequest) (*RemoveVertexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVertex not implemented")
+}
+func (UnimplementedNetworkServer) RemoveEdge(context.Context, *RemoveEdgeRequest) (*RemoveEdgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveEdge not implemented")
+}
+func (UnimplementedNetworkServer) UpdateProperties(context.Context, *UpdatePropertiesRequest) (*UpdatePropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProperties not implemented")
+}
+func (UnimplementedNetworkServer) AnalyzeGraph(context.Context, *AnalyzeGraphRequest) (*AnalyzeGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeGraph not implemented")
+}
+func (UnimplementedNetworkServer) MaxFlow(context.Context, *MaxFlowRequest) (*MaxFlowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MaxFlow not implemented")
+}
 func (UnimplementedNetworkServer) RequestSolution(context.Context, *SolveRequest) (*SolveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RequestSolution not implemented")
 }
+func (UnimplementedNetworkServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedNetworkServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedNetworkServer) CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
 func (UnimplementedNetworkServer) SetCBSLocation(context.Context, *SetCBSRequest) (*SetCBSResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetCBSLocation not implemented")
 }
@@ -185,6 +433,78 @@ func _Network_DeleteGraph_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Network_ListGraphs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGraphsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).ListGraphs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_ListGraphs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).ListGraphs(ctx, req.(*ListGraphsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_DeriveGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).DeriveGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_DeriveGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).DeriveGraph(ctx, req.(*DeriveGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_LoadGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).LoadGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_LoadGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).LoadGraph(ctx, req.(*LoadGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_DiffGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).DiffGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_DiffGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).DiffGraph(ctx, req.(*DiffGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Network_ShowGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShowGraphRequest)
 	if err := dec(in); err != nil {
@@ -221,6 +541,153 @@ func _Network_GetGraph_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Network_RenderGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).RenderGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_RenderGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).RenderGraph(ctx, req.(*RenderGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_SnapshotGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).SnapshotGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_SnapshotGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).SnapshotGraph(ctx, req.(*SnapshotGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_WatchGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGraphRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServer).WatchGraph(m, &networkWatchGraphServer{stream})
+}
+
+type Network_WatchGraphServer interface {
+	Send(*WatchGraphResponse) error
+	grpc.ServerStream
+}
+
+type networkWatchGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkWatchGraphServer) Send(m *WatchGraphResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Network_RemoveVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVertexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).RemoveVertex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_RemoveVertex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).RemoveVertex(ctx, req.(*RemoveVertexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_RemoveEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).RemoveEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_RemoveEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).RemoveEdge(ctx, req.(*RemoveEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_UpdateProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePropertiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).UpdateProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_UpdateProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).UpdateProperties(ctx, req.(*UpdatePropertiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_AnalyzeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).AnalyzeGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_AnalyzeGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).AnalyzeGraph(ctx, req.(*AnalyzeGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_MaxFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaxFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).MaxFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_MaxFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).MaxFlow(ctx, req.(*MaxFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Network_RequestSolution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SolveRequest)
 	if err := dec(in); err != nil {
@@ -239,6 +706,60 @@ func _Network_RequestSolution_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Network_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).ListReservations(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Network_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Network_SetCBSLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetCBSRequest)
 	if err := dec(in); err != nil {
@@ -272,6 +793,22 @@ var Network_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGraph",
 			Handler:    _Network_DeleteGraph_Handler,
 		},
+		{
+			MethodName: "ListGraphs",
+			Handler:    _Network_ListGraphs_Handler,
+		},
+		{
+			MethodName: "DeriveGraph",
+			Handler:    _Network_DeriveGraph_Handler,
+		},
+		{
+			MethodName: "LoadGraph",
+			Handler:    _Network_LoadGraph_Handler,
+		},
+		{
+			MethodName: "DiffGraph",
+			Handler:    _Network_DiffGraph_Handler,
+		},
 		{
 			MethodName: "ShowGraph",
 			Handler:    _Network_ShowGraph_Handler,
@@ -280,15 +817,61 @@ var Network_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetGraph",
 			Handler:    _Network_GetGraph_Handler,
 		},
+		{
+			MethodName: "RenderGraph",
+			Handler:    _Network_RenderGraph_Handler,
+		},
+		{
+			MethodName: "SnapshotGraph",
+			Handler:    _Network_SnapshotGraph_Handler,
+		},
+		{
+			MethodName: "RemoveVertex",
+			Handler:    _Network_RemoveVertex_Handler,
+		},
+		{
+			MethodName: "RemoveEdge",
+			Handler:    _Network_RemoveEdge_Handler,
+		},
+		{
+			MethodName: "UpdateProperties",
+			Handler:    _Network_UpdateProperties_Handler,
+		},
+		{
+			MethodName: "AnalyzeGraph",
+			Handler:    _Network_AnalyzeGraph_Handler,
+		},
+		{
+			MethodName: "MaxFlow",
+			Handler:    _Network_MaxFlow_Handler,
+		},
 		{
 			MethodName: "RequestSolution",
 			Handler:    _Network_RequestSolution_Handler,
 		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _Network_ListReservations_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _Network_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _Network_CommitReservation_Handler,
+		},
 		{
 			MethodName: "SetCBSLocation",
 			Handler:    _Network_SetCBSLocation_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGraph",
+			Handler:       _Network_WatchGraph_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "network.proto",
 }
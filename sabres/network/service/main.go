@@ -1,56 +1,52 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"net"
-	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"gitlab.com/mergetb/tech/stor"
 	"google.golang.org/grpc"
-	cbs "pulwar.isi.edu/sabres/cbs/cbs/service/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	ipkg "pulwar.isi.edu/sabres/orchestrator/inventory/pkg"
 	inventory "pulwar.isi.edu/sabres/orchestrator/inventory/protocol"
+	config "pulwar.isi.edu/sabres/orchestrator/pkg"
+	"pulwar.isi.edu/sabres/orchestrator/sabres/network/constraint"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/network/graph"
 	"pulwar.isi.edu/sabres/orchestrator/sabres/network/pkg"
 	proto "pulwar.isi.edu/sabres/orchestrator/sabres/network/protocol"
 )
 
 var (
-	GlobalGraph *graph.Graph
-	CBSHost     string
-	mutex       sync.Mutex
-)
+	mutex          sync.Mutex
+	EtcdConfigPath string = "/var/orchestrator/config.cfg"
 
-// TODO: A node with physical and virtual resources overlap, so some
-// work in future towards how phy resources are allocated as virt,
-// and then how reservation affects both
+	// WatchRetry is the wait before the inventory watch is started again
+	// after it failed
+	WatchRetry = 5 * time.Second
+)
 
 // for now, assume either phy or virt
 func addVertex(G *graph.Graph, io *inventory.ResourceItem) error {
-	ma := make(map[string]string)
-	if io.Phy != nil {
-		ma["cpu"] = fmt.Sprintf("%d", io.Phy.Cores)
-		ma["mem"] = fmt.Sprintf("%d", io.Phy.Memory)
-		ma["disk"] = fmt.Sprintf("%d", io.Phy.Storage)
-	} else {
-		if io.Virt != nil {
-			ma["cpu"] = fmt.Sprintf("%d", io.Virt.Cores)
-			ma["mem"] = fmt.Sprintf("%d", io.Virt.Memory)
-			ma["disk"] = fmt.Sprintf("%d", io.Virt.Storage)
-		}
+	ma, err := pkg.ResourceProperties(io)
+	if err != nil {
+		log.Errorf("Bad resource %s: %v\n", io.Uuid, err)
+		return err
 	}
 
 	log.Infof("Adding vertex: %s\n", io.Uuid)
 
-	_, err := G.AddVertex(io.Uuid, "", ma)
+	_, err = G.AddVertex(io.Uuid, "", ma)
 	if err != nil {
 		if err == graph.ErrVertexAlreadyExists {
 			return err
@@ -156,14 +152,11 @@ func createInventoryGraph() (*graph.Graph, error) {
 
 					// if not add them manually now
 
-					ma := make(map[string]string)
-
-					ma["bw"] = fmt.Sprintf("%d", link.Bandwidth)
-					ma["lat"] = fmt.Sprintf("%d", link.Latency)
-					ma["jit"] = fmt.Sprintf("%d", link.Jitter)
-					ma["uuid"] = link.Uuid
-					ma["name"] = net.Name
-					ma["selector"] = io.Resource.Parent
+					ma, err := pkg.LinkProperties(io.Resource, link)
+					if err != nil {
+						log.Errorf("Bad link %s: %v\n", link.Uuid, err)
+						continue
+					}
 
 					srcV := &graph.Vertex{Name: src}
 					dstV := &graph.Vertex{Name: dst}
@@ -193,6 +186,9 @@ type NetworkServer struct {
 	proto.UnimplementedNetworkServer
 }
 
+// CreateGraph rebuilds the graph from the whole inventory.  The graph is
+// kept up to date with inventory changes after that, a rebuild is the
+// fallback when it is not.
 func (s *NetworkServer) CreateGraph(ctx context.Context, req *proto.CreateGraphRequest) (*proto.CreateGraphResponse, error) {
 
 	if req == nil {
@@ -209,9 +205,106 @@ func (s *NetworkServer) CreateGraph(ctx context.Context, req *proto.CreateGraphR
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	GlobalGraph = g
+	var diff string
+	_, err = pkg.Store(req.GraphName).Replace(pkg.KindInventory, "", func(old *graph.Graph) (*graph.Graph, error) {
+		var err error
+		diff, err = logDiff(old, g)
+		return g, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.CreateGraphResponse{Diff: diff}, nil
+}
+
+// logDiff logs the changes between two graphs and returns them json encoded
+func logDiff(old, g *graph.Graph) (string, error) {
+	d := graph.Diff(old, g)
+	if d.Empty() {
+		log.Infof("graph unchanged")
+	} else {
+		log.Infof("graph changes:\n%s", d.String())
+	}
+
+	out, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func (s *NetworkServer) DiffGraph(ctx context.Context, req *proto.DiffGraphRequest) (*proto.DiffGraphResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("DiffGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	var g *graph.Graph
+	var err error
+	if req.Graph != "" {
+		g, err = graph.Decode(req.Format, []byte(req.Graph))
+	} else {
+		g, err = createInventoryGraph()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	old, err := pkg.Store(req.GraphName).Get()
+	if err != nil && !errors.Is(err, pkg.ErrNoGraph) {
+		return nil, err
+	}
+
+	d := graph.Diff(old, g)
+
+	out, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
 
-	return &proto.CreateGraphResponse{}, nil
+	return &proto.DiffGraphResponse{Diff: string(out)}, nil
+}
+
+func (s *NetworkServer) LoadGraph(ctx context.Context, req *proto.LoadGraphRequest) (*proto.LoadGraphResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("LoadGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	format := req.Format
+	if format == "" {
+		format = graph.FormatDot
+	}
+
+	g, err := graph.Decode(format, []byte(req.Graph))
+	if err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	_, err = pkg.Store(req.GraphName).Replace(pkg.KindLoaded, "", func(old *graph.Graph) (*graph.Graph, error) {
+		_, err := logDiff(old, g)
+		return g, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("loaded %s graph %s: %d vertices, %d edges", format, g.Name, len(g.Vertices), len(g.Edges))
+
+	return &proto.LoadGraphResponse{
+		Vertices: int64(len(g.Vertices)),
+		Edges:    int64(len(g.Edges)),
+	}, nil
 }
 
 func (s *NetworkServer) DeleteGraph(ctx context.Context, req *proto.DeleteGraphRequest) (*proto.DeleteGraphResponse, error) {
@@ -224,11 +317,115 @@ func (s *NetworkServer) DeleteGraph(ctx context.Context, req *proto.DeleteGraphR
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	GlobalGraph = nil
+	err := pkg.Store(req.GraphName).Delete()
+	if err != nil {
+		return nil, err
+	}
 
 	return &proto.DeleteGraphResponse{}, nil
 }
 
+func graphInfo(sg *pkg.StoredGraph) *proto.GraphInfo {
+	return &proto.GraphInfo{
+		Name:         sg.Name,
+		Kind:         sg.Kind,
+		Source:       sg.Source,
+		VertexFilter: sg.VertexFilter,
+		EdgeFilter:   sg.EdgeFilter,
+		Vertices:     int64(sg.Vertices),
+		Edges:        int64(sg.Edges),
+	}
+}
+
+func (s *NetworkServer) ListGraphs(ctx context.Context, req *proto.ListGraphsRequest) (*proto.ListGraphsResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("ListGraphs: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	graphs, err := pkg.ListGraphs()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ListGraphsResponse{}
+	for _, sg := range graphs {
+		resp.Graphs = append(resp.Graphs, graphInfo(sg))
+	}
+
+	return resp, nil
+}
+
+func (s *NetworkServer) DeriveGraph(ctx context.Context, req *proto.DeriveGraphRequest) (*proto.DeriveGraphResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("DeriveGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	if req.GraphName == "" {
+		return nil, fmt.Errorf("name of the new graph not given")
+	}
+
+	source := req.Source
+	if source == "" {
+		source = pkg.DefaultGraphName
+	}
+
+	edgeFilter, err := graph.ParseFilter(req.EdgeFilter)
+	if err != nil {
+		return nil, err
+	}
+	edgeExpr := graph.SelectorFilter(req.Selectors...).And(edgeFilter).String()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	store := pkg.Store(req.GraphName)
+
+	if !req.Copy {
+		sg, err := store.CreateView(source, req.VertexFilter, edgeExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("view %s of %s: vertices [%s] edges [%s]", sg.Name, source, sg.VertexFilter, sg.EdgeFilter)
+
+		return &proto.DeriveGraphResponse{Graph: graphInfo(sg)}, nil
+	}
+
+	if _, err := store.Info(); err == nil {
+		return nil, fmt.Errorf("%w: %s", pkg.ErrGraphExists, req.GraphName)
+	}
+
+	src, err := pkg.Store(source).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := pkg.ViewGraph(src, req.VertexFilter, edgeExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = store.Replace(pkg.KindCopy, source, func(*graph.Graph) (*graph.Graph, error) {
+		return g, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sg, err := store.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("copy %s of %s: %d vertices, %d edges", sg.Name, source, sg.Vertices, sg.Edges)
+
+	return &proto.DeriveGraphResponse{Graph: graphInfo(sg)}, nil
+}
+
 func (s *NetworkServer) ShowGraph(ctx context.Context, req *proto.ShowGraphRequest) (*proto.ShowGraphResponse, error) {
 	if req == nil {
 		errMsg := fmt.Sprintf("ShowGraph: Nil Request")
@@ -239,11 +436,15 @@ func (s *NetworkServer) ShowGraph(ctx context.Context, req *proto.ShowGraphReque
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	if GlobalGraph == nil {
+	g, err := pkg.Store(req.GraphName).Get()
+	if errors.Is(err, pkg.ErrNoGraph) {
 		return &proto.ShowGraphResponse{Exists: false}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	dotviz, err := GlobalGraph.DotViz()
+	dotviz, err := g.DotViz()
 	if err != nil {
 		return nil, err
 	}
@@ -261,16 +462,315 @@ func (s *NetworkServer) GetGraph(ctx context.Context, req *proto.GetGraphRequest
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	if GlobalGraph == nil {
-		return nil, fmt.Errorf("graph not defined. run create first.")
+	var g *graph.Graph
+	var version string
+	var err error
+	if req.Snapshot != "" {
+		g, version, err = pkg.ReadSnapshot(req.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		g, err = pkg.Store(req.GraphName).Get()
+		if err != nil {
+			return nil, err
+		}
+
+		version, err = g.Fingerprint()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Residual {
+		ledger, err := pkg.ReadLedger(req.GraphName)
+		if err != nil {
+			return nil, err
+		}
+
+		g, err = pkg.ResidualGraph(g, ledger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.VertexFilter != "" || req.EdgeFilter != "" {
+		g, err = pkg.ViewGraph(g, req.VertexFilter, req.EdgeFilter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	encGraph, err := g.Encode(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetGraphResponse{Graph: encGraph, Version: version}, nil
+}
+
+func (s *NetworkServer) WatchGraph(req *proto.WatchGraphRequest, stream proto.Network_WatchGraphServer) error {
+	if req == nil {
+		errMsg := fmt.Sprintf("WatchGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return fmt.Errorf("%s", errMsg)
+	}
+
+	// the watch holds no lock, changes are read from etcd as they are made
+	return pkg.Store(req.GraphName).Watch(stream.Context(), req.Initial, func(gc *pkg.GraphChange) error {
+		resp := &proto.WatchGraphResponse{Revision: gc.Revision}
+		for _, ev := range gc.Events {
+			resp.Events = append(resp.Events, &proto.GraphEvent{
+				Action:     ev.Action,
+				Kind:       ev.Kind,
+				Name:       ev.Name,
+				Vertices:   ev.Vertices,
+				Properties: ev.Properties,
+				Unset:      ev.Unset,
+			})
+		}
+		return stream.Send(resp)
+	})
+}
+
+func (s *NetworkServer) SnapshotGraph(ctx context.Context, req *proto.SnapshotGraphRequest) (*proto.SnapshotGraphResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("SnapshotGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	g, err := pkg.Store(req.GraphName).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	if req.Name != "" {
+		names = append(names, req.Name)
+	}
+
+	version, err := pkg.SaveSnapshot(g, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("graph snapshot %s: %s\n", req.Name, version)
+
+	return &proto.SnapshotGraphResponse{Version: version}, nil
+}
+
+func (s *NetworkServer) RenderGraph(ctx context.Context, req *proto.RenderGraphRequest) (*proto.RenderGraphResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("RenderGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	g, err := pkg.Store(req.GraphName).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &graph.RenderOptions{
+		Format:       req.Format,
+		ColorBy:      req.ColorBy,
+		WidthBy:      req.WidthBy,
+		VertexLabels: req.VertexLabels,
+		Highlight:    req.Highlight,
+	}
+	if len(req.EdgeLabels) > 0 {
+		opts.EdgeLabels = req.EdgeLabels
+	}
+
+	image, err := g.Render(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RenderGraphResponse{Image: image}, nil
+}
+
+func (s *NetworkServer) RemoveVertex(ctx context.Context, req *proto.RemoveVertexRequest) (*proto.RemoveVertexResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("RemoveVertex: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var removed []*graph.Edge
+	_, err := pkg.Store(req.GraphName).Update(func(g *graph.Graph) (*graph.Graph, error) {
+		if g == nil {
+			return nil, pkg.ErrNoGraph
+		}
+
+		var err error
+		removed, err = g.RemoveVertex(req.Name)
+		return g, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]string, 0, len(removed))
+	for _, e := range removed {
+		edges = append(edges, e.ID())
+	}
+
+	return &proto.RemoveVertexResponse{Edges: edges}, nil
+}
+
+func (s *NetworkServer) RemoveEdge(ctx context.Context, req *proto.RemoveEdgeRequest) (*proto.RemoveEdgeResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("RemoveEdge: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	_, err := pkg.Store(req.GraphName).Update(func(g *graph.Graph) (*graph.Graph, error) {
+		if g == nil {
+			return nil, pkg.ErrNoGraph
+		}
+
+		return g, g.RemoveEdge(req.Uuid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RemoveEdgeResponse{}, nil
+}
+
+func (s *NetworkServer) UpdateProperties(ctx context.Context, req *proto.UpdatePropertiesRequest) (*proto.UpdatePropertiesResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("UpdateProperties: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	if (req.Vertex == "") == (req.Edge == "") {
+		return nil, fmt.Errorf("exactly one of vertex or edge must be set")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	_, err := pkg.Store(req.GraphName).Update(func(g *graph.Graph) (*graph.Graph, error) {
+		if g == nil {
+			return nil, pkg.ErrNoGraph
+		}
+
+		if req.Vertex != "" {
+			return g, g.UpdateVertexProperties(req.Vertex, req.Set, req.Unset)
+		}
+		return g, g.UpdateEdgeProperties(req.Edge, req.Set, req.Unset)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	jsonGraph, err := GlobalGraph.ToJson()
+	return &proto.UpdatePropertiesResponse{}, nil
+}
+
+func (s *NetworkServer) AnalyzeGraph(ctx context.Context, req *proto.AnalyzeGraphRequest) (*proto.AnalyzeGraphResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("AnalyzeGraph: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	g, err := pkg.Store(req.GraphName).Get()
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.GetGraphResponse{Graph: jsonGraph}, nil
+	var analyses []*graph.Analysis
+	if req.Selector != "" {
+		analyses = []*graph.Analysis{g.AnalyzeSelector(req.Selector)}
+	} else if req.PerSelector {
+		analyses = g.AnalyzeBySelector()
+	} else {
+		analyses = []*graph.Analysis{g.Analyze()}
+	}
+
+	resp := &proto.AnalyzeGraphResponse{}
+	for _, a := range analyses {
+		pa := &proto.Analysis{
+			Selector:           a.Selector,
+			ArticulationPoints: a.ArticulationPoints,
+		}
+		for _, c := range a.Components {
+			pa.Components = append(pa.Components, &proto.Component{Vertices: c})
+		}
+		for _, e := range a.Bridges {
+			pa.Bridges = append(pa.Bridges, e.ID())
+		}
+
+		log.Infof("analysis %q: %d components, %d articulation points, %d bridges",
+			a.Selector, len(a.Components), len(a.ArticulationPoints), len(a.Bridges))
+
+		resp.Analyses = append(resp.Analyses, pa)
+	}
+
+	return resp, nil
+}
+
+func (s *NetworkServer) MaxFlow(ctx context.Context, req *proto.MaxFlowRequest) (*proto.MaxFlowResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("MaxFlow: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	g, err := pkg.Store(req.GraphName).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := g.MaxFlowSets(req.Sources, req.Sinks, &graph.FlowOptions{
+		Selector: req.Selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.MaxFlowResponse{
+		Value:    f.Value,
+		Feasible: f.Value >= float64(req.Bandwidth),
+	}
+	for _, e := range f.Cut {
+		resp.Cut = append(resp.Cut, e.ID())
+	}
+	for _, ef := range f.Edges {
+		resp.Flows = append(resp.Flows, &proto.EdgeFlow{
+			Edge:  ef.Edge.ID(),
+			From:  ef.From,
+			To:    ef.To,
+			Value: ef.Value,
+		})
+	}
+
+	log.Infof("max flow %v -> %v: %f (requested %d)", req.Sources, req.Sinks, f.Value, req.Bandwidth)
+
+	return resp, nil
 }
 
 func (s *NetworkServer) RequestSolution(ctx context.Context, req *proto.SolveRequest) (*proto.SolveResponse, error) {
@@ -280,130 +780,236 @@ func (s *NetworkServer) RequestSolution(ctx context.Context, req *proto.SolveReq
 		return nil, fmt.Errorf("%s", errMsg)
 	}
 
-	if req.Constraints == nil {
-		return nil, fmt.Errorf("constraints not given")
+	cons, err := constraint.ParseAll(req.Constraints)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(req.Constraints) == 0 {
-		return nil, fmt.Errorf("constraints not found")
+	if req.HoldTtl < 0 {
+		return nil, fmt.Errorf("hold ttl %d: must not be negative", req.HoldTtl)
 	}
+	ttl := time.Duration(req.HoldTtl) * time.Second
 
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	if CBSHost == "" {
-		return nil, fmt.Errorf("CBSHost has not been set yet, use SetCBSLocation first")
+	solvers, err := pkg.Solvers(req.Solver)
+	if err != nil {
+		return nil, err
 	}
 
-	if GlobalGraph == nil {
-		return nil, fmt.Errorf("graph not found, must be created first.")
+	g, err := pkg.Store(req.GraphName).Get()
+	if err != nil {
+		return nil, err
 	}
 
-	gg, err := GlobalGraph.DeepCopy()
+	err = constraint.Resolve(cons, g)
 	if err != nil {
 		return nil, err
 	}
 
-	c := &cbs.CBSRequest{}
-	modConstraints := req.Constraints
-	for _, cc := range modConstraints {
-		if cc.Object == "cpu" {
-			// for cbs, we need a mechanism to tell cbs that we specifically
-			// want to include this node in our graph
-			if len(cc.Vertices) < 1 {
-				return nil, fmt.Errorf("constraint not formatted correctly")
-			}
-			v := cc.Vertices[0]
+	// the fingerprint of the graph the solution is for, so slices can tell
+	// if the topology changed since.  A reservation keeps a snapshot of it.
+	version, err := g.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
 
-			ok, vertex := gg.FindVertex(&graph.Vertex{Name: v})
-			if !ok {
-				return nil, fmt.Errorf("couldnt find constraint vertex in graph")
-			}
+	log.Infof("solving against graph version %s\n", version)
 
-			vertex.Properties["endpoint"] = "yes"
-			log.Infof("Updating vertex info: %v", vertex)
-		}
+	// the solver sees the capacity left after the reservations, the
+	// snapshot is of the capacity the graph has
+	ledger, err := pkg.ReadLedger(req.GraphName)
+	if err != nil {
+		return nil, err
 	}
+	capacity := g
 
-	c.Constraints = modConstraints
+	g, err = pkg.ResidualGraph(g, ledger)
+	if err != nil {
+		return nil, err
+	}
 
-	// TODO: selector only to be used on edges
-	// todo is to manage that for later on with more variables
-	// that work on nodes,  And there can only be one selector
-	selector := ""
+	// constraint selectors pick edges, several selectors keep the edges of
+	// any of them
+	selectors := make([]string, 0)
+	seen := make(map[string]bool)
 	for _, c := range req.Constraints {
-		if c.Selector != "" {
-			if selector != "" && c.Selector != selector {
-				return nil, fmt.Errorf("Too many edge selectors given: %s, %s. Can only use 1", selector, c.Selector)
-			}
-			selector = c.Selector
+		if c.Selector != "" && !seen[c.Selector] {
+			seen[c.Selector] = true
+			selectors = append(selectors, c.Selector)
 		}
 	}
 
-	log.Infof("selector for solving: %s\n", selector)
+	edgeFilter, err := graph.ParseFilter(req.EdgeFilter)
+	if err != nil {
+		return nil, err
+	}
+	edgeFilter = graph.SelectorFilter(selectors...).And(edgeFilter)
 
-	log.Infof("global graph:\n")
-	gg.PrintGraph()
+	vertexFilter, err := graph.ParseFilter(req.VertexFilter)
+	if err != nil {
+		return nil, err
+	}
 
-	if selector != "" {
-		g, err := graph.PruneGraph(gg, selector)
-		if err != nil {
-			return nil, err
-		}
+	log.Infof("filters for solving: vertices [%s] edges [%s]\n", vertexFilter, edgeFilter)
+
+	log.Infof("global graph:\n")
+	g.PrintGraph()
 
-		k, err := g.DeepCopy()
+	if !vertexFilter.Empty() || !edgeFilter.Empty() {
+		g, err = g.Subgraph(vertexFilter, edgeFilter)
 		if err != nil {
 			return nil, err
 		}
 
-		log.Infof("after prune graph:\n")
-		k.PrintGraph()
-		c.Graph = k
+		log.Infof("after filter graph:\n")
+		g.PrintGraph()
 
 	} else {
-		log.Infof("selector not set, using primary graph")
-		var err error
-		c.Graph, err = gg.DeepCopy()
+		log.Infof("filters not set, using primary graph")
+	}
+
+	// the first solver that answers wins, when cbs can not be reached the
+	// native solver still can.  An answer that there is no solution stands.
+	for i, solver := range solvers {
+		resp, err := solver.Solve(ctx, g, cons)
 		if err != nil {
+			if errors.Is(err, pkg.ErrSolverUnavailable) && i+1 < len(solvers) {
+				log.Warnf("%s solver failed, trying %s: %v\n", solver.Name(), solvers[i+1].Name(), err)
+				continue
+			}
 			return nil, err
 		}
+
+		if req.Reserve != "" {
+			r, err := pkg.ReserveSolution(req.GraphName, capacity, cons, resp, req.Reserve, ttl)
+			if err != nil {
+				return nil, err
+			}
+			if ttl > 0 {
+				log.Infof("holding %s in the ledger of %s for %s\n", r.Name, ledger.Graph, ttl)
+			} else {
+				log.Infof("reserved %s in the ledger of %s\n", r.Name, ledger.Graph)
+			}
+		}
+
+		return &proto.SolveResponse{Response: resp, GraphVersion: version, Solver: solver.Name()}, nil
 	}
 
-	// take constraints, create json
-	cons, err := json.Marshal(c)
+	return nil, fmt.Errorf("no solver")
+}
+
+func (s *NetworkServer) ListReservations(ctx context.Context, req *proto.ListReservationsRequest) (*proto.ListReservationsResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("ListReservations: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	ledger, err := pkg.ReadLedger(req.GraphName)
 	if err != nil {
-		log.Errorf("erro marshaling: %v\n", err)
 		return nil, err
 	}
 
-	log.Infof("Request to send to CBS: %s\n", cons)
+	resp := &proto.ListReservationsResponse{GraphName: ledger.Graph}
+	for _, r := range ledger.Sorted() {
+		resp.Reservations = append(resp.Reservations, reservationInfo(r))
+	}
+	for _, h := range ledger.SortedHolds() {
+		r := reservationInfo(h.Reservation)
+		r.Expires = h.Expires.Format(time.RFC3339)
+		resp.Reservations = append(resp.Reservations, r)
+	}
+
+	return resp, nil
+}
+
+func (s *NetworkServer) ReleaseReservation(ctx context.Context, req *proto.ReleaseReservationRequest) (*proto.ReleaseReservationResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("ReleaseReservation: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
 
-	//POST request to CBSHost
-	endpoint := fmt.Sprintf("http://%s/cbs", CBSHost)
-	request, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(cons))
+	r, err := pkg.Release(req.GraphName, req.Name)
+	if errors.Is(err, pkg.ErrNoReservation) || errors.Is(err, pkg.ErrNoGraph) {
+		// callers tell nothing to release from a release that failed
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(request)
+	log.Infof("released reservation %s\n", r.Name)
+
+	// the snapshot of the reservation goes with it, and those of holds that
+	// ran out
+	_, err = pkg.PruneSnapshots()
 	if err != nil {
-		return nil, err
+		log.Warnf("prune snapshots: %v", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	return &proto.ReleaseReservationResponse{Reservation: reservationInfo(r)}, nil
+}
+
+func (s *NetworkServer) CommitReservation(ctx context.Context, req *proto.CommitReservationRequest) (*proto.CommitReservationResponse, error) {
+	if req == nil {
+		errMsg := fmt.Sprintf("CommitReservation: Nil Request")
+		log.Errorf("%s", errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	r, err := pkg.CommitReservation(req.GraphName, req.Name)
 	if err != nil {
 		return nil, err
 	}
-	log.Infof("resp from cbs: %s\n", string(body))
 
-	// reach out to cbs for it to its thing
+	log.Infof("committed reservation %s\n", r.Name)
+
+	return &proto.CommitReservationResponse{Reservation: reservationInfo(r)}, nil
+}
+
+func reservationInfo(r *pkg.Reservation) *proto.Reservation {
+	out := &proto.Reservation{
+		Name:    r.Name,
+		Created: r.Created.Format(time.RFC3339),
+	}
 
-	// TODO: Reservation system
+	add := func(kind string, amounts pkg.Amounts) {
+		names := make([]string, 0, len(amounts))
+		for name := range amounts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
 
-	return &proto.SolveResponse{Response: string(body)}, nil
+		for _, name := range names {
+			keys := make([]string, 0, len(amounts[name]))
+			for key := range amounts[name] {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				out.Resources = append(out.Resources, &proto.ReservedResource{
+					Kind:     kind,
+					Name:     name,
+					Property: key,
+					Amount:   amounts[name][key],
+				})
+			}
+		}
+	}
+	add("vertex", r.Vertices)
+	add("edge", r.Edges)
+
+	return out
 }
 
 func (s *NetworkServer) SetCBSLocation(ctx context.Context, req *proto.SetCBSRequest) (*proto.SetCBSResponse, error) {
@@ -420,18 +1026,126 @@ func (s *NetworkServer) SetCBSLocation(ctx context.Context, req *proto.SetCBSReq
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	CBSHost = fmt.Sprintf("%s:%s", req.Host, req.Port)
-	log.Infof("CBS Host set to: %s\n", CBSHost)
+	solver, err := pkg.WriteSolver(req.Host, req.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("CBS Host set to: %s\n", solver.Endpoint())
 
 	return &proto.SetCBSResponse{}, nil
 }
 
+// maintainGraph applies inventory changes to the graphs built from
+// inventory as they happen.  Whenever the watch has to start again, changes
+// may have been missed, so the graphs are rebuilt from inventory first.
+func maintainGraph(ctx context.Context) {
+	for {
+		rev, err := pkg.InventoryRevision()
+		if err == nil {
+			err = resyncGraphs()
+		}
+		if err == nil {
+			err = pkg.WatchInventory(ctx, rev+1, applyInventoryEvent)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Warnf("inventory watch stopped, resync in %s: %v", WatchRetry, err)
+		time.Sleep(WatchRetry)
+	}
+}
+
+// inventoryGraphs returns the names of the graphs built from inventory
+func inventoryGraphs() ([]string, error) {
+	all, err := pkg.ListGraphs()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, sg := range all {
+		if sg.Kind == pkg.KindInventory {
+			names = append(names, sg.Name)
+		}
+	}
+	return names, nil
+}
+
+// resyncGraphs rebuilds the graphs built from inventory
+func resyncGraphs() error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	names, err := inventoryGraphs()
+	if err != nil || len(names) == 0 {
+		return err
+	}
+
+	g, err := createInventoryGraph()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		_, err := pkg.Store(name).Update(func(old *graph.Graph) (*graph.Graph, error) {
+			if old == nil {
+				return nil, pkg.ErrNoGraph
+			}
+
+			ng, err := g.DeepCopy()
+			if err != nil {
+				return nil, err
+			}
+
+			log.Infof("resync graph %s", name)
+			_, err = logDiff(old, ng)
+			return ng, err
+		})
+		if err != nil && !errors.Is(err, pkg.ErrNoGraph) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyInventoryEvent(ev *pkg.InventoryEvent) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	log.Infof("inventory change: %s\n", ev)
+
+	names, err := inventoryGraphs()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		_, err := pkg.Store(name).Update(func(g *graph.Graph) (*graph.Graph, error) {
+			if g == nil {
+				return nil, pkg.ErrNoGraph
+			}
+
+			return g, pkg.ApplyInventoryEvent(g, ev)
+		})
+		if err != nil && !errors.Is(err, pkg.ErrNoGraph) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func main() {
 	var debug bool
 	var port int
+	var watch bool
 
 	flag.IntVar(&port, "port", pkg.DefaultNetworkPort, "set the Networkd control port")
 	flag.BoolVar(&debug, "debug", false, "enable extra debug logging")
+	flag.BoolVar(&watch, "watch", true, "keep the graph up to date with inventory changes")
 
 	portStr := os.Getenv("NETWORKPORT")
 	if portStr != "" {
@@ -460,6 +1174,45 @@ func main() {
 		log.SetLevel(log.InfoLevel)
 	}
 
+	cfg, err := config.LoadConfig(EtcdConfigPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	// read in environment variables for container
+	err = config.ReadENVSettings(cfg)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	etcdCfg, err := config.SetEtcdSettings(cfg)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	stor.SetConfig(*etcdCfg)
+
+	// pick up the graphs and solver from before a restart
+	graphs, err := pkg.ListGraphs()
+	if err == nil {
+		for _, sg := range graphs {
+			log.Infof("%s graph %s reloaded: %d vertices, %d edges", sg.Kind, sg.Name, sg.Vertices, sg.Edges)
+		}
+	} else {
+		log.Warnf("no graphs reloaded: %v", err)
+	}
+
+	solver, err := pkg.ReadSolver()
+	if err == nil {
+		log.Infof("CBS Host reloaded: %s\n", solver.Endpoint())
+	} else {
+		log.Warnf("no CBS Host reloaded: %v", err)
+	}
+
+	if watch {
+		go maintainGraph(context.Background())
+	}
+
 	log.Info(fmt.Sprintf("Networkd starting up on port %d", port))
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))